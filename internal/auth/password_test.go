@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckPassword(t *testing.T) {
+	hash, err := HashPassword("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		hash     string
+		password string
+		want     bool
+	}{
+		{"correct", hash, "correct horse", true},
+		{"wrong", hash, "correct horsE", false},
+		{"empty password", hash, "", false},
+		{"unknown user", "", "correct horse", false},
+		{"unknown user with the dummy password", "", "not a real password", false},
+		{"invalid hash", "not a hash", "correct horse", false},
+	}
+	for _, tt := range tests {
+		if got := CheckPassword(tt.hash, tt.password); got != tt.want {
+			t.Errorf("%s: CheckPassword = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHashPasswordLength(t *testing.T) {
+	if _, err := HashPassword(strings.Repeat("a", MaxPasswordBytes)); err != nil {
+		t.Errorf("HashPassword of %d bytes: %v", MaxPasswordBytes, err)
+	}
+	// bcrypt would ignore everything after the limit, so longer passwords
+	// must be refused rather than truncated.
+	if _, err := HashPassword(strings.Repeat("a", MaxPasswordBytes+1)); err == nil {
+		t.Errorf("HashPassword of %d bytes succeeded", MaxPasswordBytes+1)
+	}
+}
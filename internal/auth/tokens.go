@@ -0,0 +1,137 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fitness-framework-api/internal/models"
+)
+
+const (
+	DefaultAccessTokenTTL  = 15 * time.Minute
+	DefaultRefreshTokenTTL = 30 * 24 * time.Hour
+	// APIKeyPrefix starts every API key, which tells them apart from access
+	// tokens in the Authorization header.
+	APIKeyPrefix = "ffk_"
+)
+
+var ErrInvalidToken = errors.New("invalid or expired token")
+
+// Identity is the authenticated caller of a request. SessionID is set for
+// access tokens and APIKeyID for API keys.
+type Identity struct {
+	UserID    primitive.ObjectID
+	Role      models.Role
+	SessionID primitive.ObjectID
+	APIKeyID  primitive.ObjectID
+}
+
+type claims struct {
+	UserID    primitive.ObjectID `json:"sub"`
+	SessionID primitive.ObjectID `json:"sid"`
+	Role      models.Role        `json:"role"`
+	ExpiresAt int64              `json:"exp"`
+}
+
+// Issuer signs and verifies access tokens. Access tokens are checked
+// without a store lookup, so they stay valid until they expire even after
+// logout or a change of role; keep AccessTTL short. Refresh tokens are
+// random and only valid while their session is stored.
+type Issuer struct {
+	Secret     []byte
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
+}
+
+func NewIssuer(secret []byte) *Issuer {
+	return &Issuer{Secret: secret, AccessTTL: DefaultAccessTokenTTL, RefreshTTL: DefaultRefreshTokenTTL}
+}
+
+// NewSecret returns a random signing secret.
+func NewSecret() []byte {
+	secret := make([]byte, 32)
+	rand.Read(secret)
+	return secret
+}
+
+func (i *Issuer) AccessToken(identity Identity, now time.Time) (string, time.Time) {
+	expires := now.Add(i.AccessTTL)
+	payload, _ := json.Marshal(claims{UserID: identity.UserID, SessionID: identity.SessionID, Role: identity.Role, ExpiresAt: expires.Unix()})
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(i.sign(encoded)), expires
+}
+
+func (i *Issuer) ParseAccessToken(token string, now time.Time) (Identity, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return Identity{}, ErrInvalidToken
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(sig, i.sign(encoded)) {
+		return Identity{}, ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Identity{}, ErrInvalidToken
+	}
+
+	var c claims
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return Identity{}, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if now.Unix() >= c.ExpiresAt {
+		return Identity{}, ErrInvalidToken
+	}
+	return Identity{UserID: c.UserID, SessionID: c.SessionID, Role: c.Role}, nil
+}
+
+func (i *Issuer) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, i.Secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
+
+// NewRefreshToken returns a random refresh token and the hash to store.
+func NewRefreshToken() (string, string) {
+	raw := make([]byte, 32)
+	rand.Read(raw)
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	return token, HashToken(token)
+}
+
+// NewAPIKey returns a random API key, the hash to store and a prefix of the
+// key that is safe to show to identify it.
+func NewAPIKey() (key, hash, prefix string) {
+	raw := make([]byte, 32)
+	rand.Read(raw)
+	key = APIKeyPrefix + base64.RawURLEncoding.EncodeToString(raw)
+	return key, HashToken(key), key[:len(APIKeyPrefix)+6]
+}
+
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+type identityKey struct{}
+
+func WithIdentity(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// IdentityFromContext returns the caller of an authenticated request.
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(Identity)
+	return identity, ok
+}
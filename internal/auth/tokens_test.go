@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fitness-framework-api/internal/models"
+)
+
+func TestAccessToken(t *testing.T) {
+	issuer := NewIssuer([]byte("secret"))
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	identity := Identity{UserID: primitive.NewObjectID(), SessionID: primitive.NewObjectID(), Role: models.RoleCoach}
+	token, expires := issuer.AccessToken(identity, now)
+	if want := now.Add(DefaultAccessTokenTTL); !expires.Equal(want) {
+		t.Errorf("token expires at %v, want %v", expires, want)
+	}
+
+	payload, signature, _ := strings.Cut(token, ".")
+	forged, _ := issuer.AccessToken(Identity{UserID: identity.UserID, SessionID: identity.SessionID, Role: models.RoleAdmin}, now)
+	forgedPayload, _, _ := strings.Cut(forged, ".")
+	otherIssuer, _ := NewIssuer([]byte("other secret")).AccessToken(identity, now)
+
+	tests := []struct {
+		name  string
+		token string
+		now   time.Time
+		valid bool
+	}{
+		{"valid", token, now, true},
+		{"just before expiry", token, expires.Add(-time.Second), true},
+		{"expired", token, expires, false},
+		{"other secret", otherIssuer, now, false},
+		{"changed payload", forgedPayload + "." + signature, now, false},
+		{"changed signature", payload + "." + base64.RawURLEncoding.EncodeToString([]byte("not the signature")), now, false},
+		{"no signature", payload, now, false},
+		{"empty", "", now, false},
+		{"signed garbage", signed(issuer, "bm90IGpzb24"), now, false},
+	}
+	for _, tt := range tests {
+		got, err := issuer.ParseAccessToken(tt.token, tt.now)
+		if !tt.valid {
+			if !errors.Is(err, ErrInvalidToken) {
+				t.Errorf("%s: error %v, want ErrInvalidToken", tt.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+		} else if got != identity {
+			t.Errorf("%s: identity %+v, want %+v", tt.name, got, identity)
+		}
+	}
+}
+
+func signed(issuer *Issuer, encoded string) string {
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(issuer.sign(encoded))
+}
+
+func TestRandomTokens(t *testing.T) {
+	refresh, hash := NewRefreshToken()
+	other, _ := NewRefreshToken()
+	if refresh == other {
+		t.Error("NewRefreshToken returned the same token twice")
+	}
+	if hash != HashToken(refresh) || hash == refresh {
+		t.Errorf("refresh token hash %q does not match HashToken", hash)
+	}
+
+	key, hash, prefix := NewAPIKey()
+	if !strings.HasPrefix(key, APIKeyPrefix) || !strings.HasPrefix(key, prefix) || len(prefix) >= len(key) {
+		t.Errorf("API key %q has prefix %q", key, prefix)
+	}
+	if hash != HashToken(key) {
+		t.Errorf("API key hash %q does not match HashToken", hash)
+	}
+}
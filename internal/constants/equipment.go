@@ -22,6 +22,33 @@ const (
 	EquipmentEZBar               = "EZ Bar"
 )
 
+const (
+	EquipmentCategoryFreeWeights = "Free Weights"
+	EquipmentCategoryMachines    = "Machines"
+	EquipmentCategoryStations    = "Benches & Racks"
+	EquipmentCategoryBodyweight  = "Bodyweight"
+)
+
+var EquipmentCategories = map[string]string{
+	EquipmentBarbell:             EquipmentCategoryFreeWeights,
+	EquipmentSquatRack:           EquipmentCategoryStations,
+	EquipmentNone:                EquipmentCategoryBodyweight,
+	EquipmentPullupBar:           EquipmentCategoryStations,
+	EquipmentDumbbells:           EquipmentCategoryFreeWeights,
+	EquipmentWeightPlates:        EquipmentCategoryFreeWeights,
+	EquipmentLatPulldownMachine:  EquipmentCategoryMachines,
+	EquipmentSmithMachine:        EquipmentCategoryMachines,
+	EquipmentCableMachine:        EquipmentCategoryMachines,
+	EquipmentFlatBench:           EquipmentCategoryStations,
+	EquipmentDeclineBench:        EquipmentCategoryStations,
+	EquipmentInclineBench:        EquipmentCategoryStations,
+	EquipmentChestPressMachine:   EquipmentCategoryMachines,
+	EquipmentLegCurlMachine:      EquipmentCategoryMachines,
+	EquipmentLegExtensionMachine: EquipmentCategoryMachines,
+	EquipmentLegPressMachine:     EquipmentCategoryMachines,
+	EquipmentEZBar:               EquipmentCategoryFreeWeights,
+}
+
 var AllEquipmentNames = []string{
 	EquipmentBarbell,
 	EquipmentSquatRack,
@@ -0,0 +1,97 @@
+package constants
+
+import "strings"
+
+const (
+	MechanicsCompound  = "compound"
+	MechanicsIsolation = "isolation"
+)
+
+var AllMechanics = []string{
+	MechanicsCompound,
+	MechanicsIsolation,
+}
+
+const (
+	ForcePush   = "push"
+	ForcePull   = "pull"
+	ForceStatic = "static"
+)
+
+var AllForces = []string{
+	ForcePush,
+	ForcePull,
+	ForceStatic,
+}
+
+const (
+	MovementPush  = "push"
+	MovementPull  = "pull"
+	MovementHinge = "hinge"
+	MovementSquat = "squat"
+	MovementLunge = "lunge"
+	MovementCarry = "carry"
+	MovementCore  = "core"
+)
+
+var AllMovementPatterns = []string{
+	MovementPush,
+	MovementPull,
+	MovementHinge,
+	MovementSquat,
+	MovementLunge,
+	MovementCarry,
+	MovementCore,
+}
+
+const (
+	LateralityBilateral  = "bilateral"
+	LateralityUnilateral = "unilateral"
+)
+
+var AllLateralities = []string{
+	LateralityBilateral,
+	LateralityUnilateral,
+}
+
+const (
+	DifficultyBeginner     = "beginner"
+	DifficultyIntermediate = "intermediate"
+	DifficultyAdvanced     = "advanced"
+)
+
+// AllDifficulties is ordered from easiest to hardest.
+var AllDifficulties = []string{
+	DifficultyBeginner,
+	DifficultyIntermediate,
+	DifficultyAdvanced,
+}
+
+func IsValidMechanics(value string) bool {
+	return containsFold(AllMechanics, value)
+}
+
+func IsValidForce(value string) bool {
+	return containsFold(AllForces, value)
+}
+
+func IsValidMovementPattern(value string) bool {
+	return containsFold(AllMovementPatterns, value)
+}
+
+func IsValidLaterality(value string) bool {
+	return containsFold(AllLateralities, value)
+}
+
+func IsValidDifficulty(value string) bool {
+	return containsFold(AllDifficulties, value)
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -14,6 +14,64 @@ const (
 	MuscleGroupFullBody  = "Full Body"
 )
 
+const (
+	MuscleQuadriceps = "Quadriceps"
+	MuscleHamstrings = "Hamstrings"
+	MuscleGlutes     = "Glutes"
+	MuscleCalves     = "Calves"
+	MuscleLats       = "Lats"
+	MuscleTraps      = "Traps"
+	MuscleRhomboids  = "Rhomboids"
+	MuscleErectors   = "Erectors"
+	MuscleFrontDelts = "Front Delts"
+	MuscleSideDelts  = "Side Delts"
+	MuscleRearDelts  = "Rear Delts"
+)
+
+const (
+	MuscleCategoryUpperBody = "Upper Body"
+	MuscleCategoryLowerBody = "Lower Body"
+	MuscleCategoryCore      = "Core"
+	MuscleCategoryFullBody  = "Full Body"
+)
+
+var MuscleGroupCategories = map[string]string{
+	MuscleGroupBack:      MuscleCategoryUpperBody,
+	MuscleGroupBiceps:    MuscleCategoryUpperBody,
+	MuscleGroupChest:     MuscleCategoryUpperBody,
+	MuscleGroupLegs:      MuscleCategoryLowerBody,
+	MuscleGroupShoulders: MuscleCategoryUpperBody,
+	MuscleGroupTriceps:   MuscleCategoryUpperBody,
+	MuscleGroupObliques:  MuscleCategoryCore,
+	MuscleGroupAbs:       MuscleCategoryCore,
+	MuscleGroupFullBody:  MuscleCategoryFullBody,
+	MuscleQuadriceps:     MuscleCategoryLowerBody,
+	MuscleHamstrings:     MuscleCategoryLowerBody,
+	MuscleGlutes:         MuscleCategoryLowerBody,
+	MuscleCalves:         MuscleCategoryLowerBody,
+	MuscleLats:           MuscleCategoryUpperBody,
+	MuscleTraps:          MuscleCategoryUpperBody,
+	MuscleRhomboids:      MuscleCategoryUpperBody,
+	MuscleErectors:       MuscleCategoryUpperBody,
+	MuscleFrontDelts:     MuscleCategoryUpperBody,
+	MuscleSideDelts:      MuscleCategoryUpperBody,
+	MuscleRearDelts:      MuscleCategoryUpperBody,
+}
+
+var MuscleParents = map[string]string{
+	MuscleQuadriceps: MuscleGroupLegs,
+	MuscleHamstrings: MuscleGroupLegs,
+	MuscleGlutes:     MuscleGroupLegs,
+	MuscleCalves:     MuscleGroupLegs,
+	MuscleLats:       MuscleGroupBack,
+	MuscleTraps:      MuscleGroupBack,
+	MuscleRhomboids:  MuscleGroupBack,
+	MuscleErectors:   MuscleGroupBack,
+	MuscleFrontDelts: MuscleGroupShoulders,
+	MuscleSideDelts:  MuscleGroupShoulders,
+	MuscleRearDelts:  MuscleGroupShoulders,
+}
+
 var AllMuscleGroupNames = []string{
 	MuscleGroupBack,
 	MuscleGroupBiceps,
@@ -26,6 +84,20 @@ var AllMuscleGroupNames = []string{
 	MuscleGroupFullBody,
 }
 
+var AllMuscleNames = []string{
+	MuscleQuadriceps,
+	MuscleHamstrings,
+	MuscleGlutes,
+	MuscleCalves,
+	MuscleLats,
+	MuscleTraps,
+	MuscleRhomboids,
+	MuscleErectors,
+	MuscleFrontDelts,
+	MuscleSideDelts,
+	MuscleRearDelts,
+}
+
 func IsValidMuscleGroup(name string) bool {
 	for _, validName := range AllMuscleGroupNames {
 		if strings.EqualFold(validName, name) {
@@ -34,3 +106,15 @@ func IsValidMuscleGroup(name string) bool {
 	}
 	return false
 }
+
+func IsValidMuscle(name string) bool {
+	if IsValidMuscleGroup(name) {
+		return true
+	}
+	for _, validName := range AllMuscleNames {
+		if strings.EqualFold(validName, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package constants
+
+import "strings"
+
+const (
+	MuscleRolePrimary    = "primary"
+	MuscleRoleSecondary  = "secondary"
+	MuscleRoleStabilizer = "stabilizer"
+)
+
+var AllMuscleRoles = []string{
+	MuscleRolePrimary,
+	MuscleRoleSecondary,
+	MuscleRoleStabilizer,
+}
+
+var MuscleRoleWeights = map[string]float64{
+	MuscleRolePrimary:    1.0,
+	MuscleRoleSecondary:  0.5,
+	MuscleRoleStabilizer: 0.25,
+}
+
+func IsValidMuscleRole(role string) bool {
+	for _, validRole := range AllMuscleRoles {
+		if strings.EqualFold(validRole, role) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package constants
+
+const (
+	SetTypeWarmup  = "warmup"
+	SetTypeWorking = "working"
+	SetTypeDrop    = "drop"
+	SetTypeFailure = "failure"
+)
+
+var AllSetTypes = []string{
+	SetTypeWarmup,
+	SetTypeWorking,
+	SetTypeDrop,
+	SetTypeFailure,
+}
+
+const (
+	WeightUnitKilograms = "kg"
+	WeightUnitPounds    = "lb"
+)
+
+var AllWeightUnits = []string{
+	WeightUnitKilograms,
+	WeightUnitPounds,
+}
+
+func IsValidSetType(value string) bool {
+	return containsFold(AllSetTypes, value)
+}
+
+func IsValidWeightUnit(value string) bool {
+	return containsFold(AllWeightUnits, value)
+}
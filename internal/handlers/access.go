@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fitness-framework-api/internal/auth"
+	"fitness-framework-api/internal/models"
+	"fitness-framework-api/internal/store"
+)
+
+// RequireAuth rejects requests without a valid access token or API key in
+// the Authorization header. The caller is available to next through
+// auth.IdentityFromContext.
+func (api *API) RequireAuth(next http.HandlerFunc) http.HandlerFunc {
+	return api.RequireRole(models.RoleUser, next)
+}
+
+// RequireRole is RequireAuth for callers with at least the given role.
+func (api *API) RequireRole(role models.Role, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			next(w, r)
+			return
+		}
+
+		identity, ok := api.authenticate(w, r)
+		if !ok {
+			return
+		}
+		if !identity.Role.Includes(role) {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+			writeError(w, http.StatusForbidden, fmt.Sprintf("this action requires the %s role", role))
+			return
+		}
+		next(w, r.WithContext(auth.WithIdentity(r.Context(), identity)))
+	}
+}
+
+// RequireRoleToWrite leaves GET and HEAD requests open to everyone, as
+// OptionalAuth does, and applies RequireRole to all other methods.
+func (api *API) RequireRoleToWrite(role models.Role, next http.HandlerFunc) http.HandlerFunc {
+	public := api.OptionalAuth(next)
+	protected := api.RequireRole(role, next)
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			public(w, r)
+			return
+		}
+		protected(w, r)
+	}
+}
+
+// OptionalAuth identifies the caller of requests that carry credentials
+// and lets anonymous requests through. Invalid credentials are rejected.
+func (api *API) OptionalAuth(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions || r.Header.Get("Authorization") == "" {
+			next(w, r)
+			return
+		}
+
+		identity, ok := api.authenticate(w, r)
+		if !ok {
+			return
+		}
+		next(w, r.WithContext(auth.WithIdentity(r.Context(), identity)))
+	}
+}
+
+func (api *API) authenticate(w http.ResponseWriter, r *http.Request) (auth.Identity, bool) {
+	scheme, token, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+	token = strings.TrimSpace(token)
+	if !strings.EqualFold(scheme, "Bearer") || token == "" {
+		unauthorized(w, "authentication required")
+		return auth.Identity{}, false
+	}
+
+	if !strings.HasPrefix(token, auth.APIKeyPrefix) {
+		identity, err := api.Auth.ParseAccessToken(token, time.Now())
+		if err != nil {
+			unauthorized(w, err.Error())
+			return auth.Identity{}, false
+		}
+		return identity, true
+	}
+
+	key, err := api.Users.FindAPIKey(r.Context(), auth.HashToken(token))
+	if errors.Is(err, store.ErrNotFound) || err == nil && key.RevokedAt != nil {
+		unauthorized(w, "invalid or revoked API key")
+		return auth.Identity{}, false
+	}
+	if err != nil {
+		api.writeStoreError(w, "check API key", err)
+		return auth.Identity{}, false
+	}
+	user, err := api.Users.GetUser(r.Context(), key.UserID)
+	if errors.Is(err, store.ErrNotFound) {
+		unauthorized(w, "invalid or revoked API key")
+		return auth.Identity{}, false
+	}
+	if err != nil {
+		api.writeStoreError(w, "check API key", err)
+		return auth.Identity{}, false
+	}
+	return auth.Identity{UserID: user.ID, Role: user.Role, APIKeyID: key.ID}, true
+}
+
+func hasRole(r *http.Request, role models.Role) bool {
+	identity, ok := auth.IdentityFromContext(r.Context())
+	return ok && identity.Role.Includes(role)
+}
+
+func unauthorized(w http.ResponseWriter, message string) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("WWW-Authenticate", `Bearer realm="api"`)
+	writeError(w, http.StatusUnauthorized, message)
+}
+
+// audit records a change to the shared catalog by the caller of r. The
+// change has already happened, so a failure to record it is only logged.
+func (api *API) audit(r *http.Request, action, target, targetID, targetName string) {
+	identity, _ := auth.IdentityFromContext(r.Context())
+	event := &models.AuditEvent{
+		Time:       time.Now().UTC(),
+		UserID:     identity.UserID,
+		Action:     action,
+		Target:     target,
+		TargetID:   targetID,
+		TargetName: targetName,
+	}
+	if !identity.APIKeyID.IsZero() {
+		event.APIKeyID = &identity.APIKeyID
+	}
+
+	slog.Info("Catalog changed", "action", action, "target", target, "id", targetID, "name", targetName, "user", identity.UserID.Hex())
+	if err := api.Audit.RecordAudit(r.Context(), event); err != nil {
+		slog.Error("Error recording audit event", "action", action, "target", target, "id", targetID, "error", err)
+	}
+}
+
+func (api *API) AuditHandler(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w, "GET, OPTIONS")
+
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		q := r.URL.Query()
+		filter := store.AuditFilter{Target: q.Get("target"), Limit: 100}
+		if user := q.Get("user"); user != "" {
+			id, err := primitive.ObjectIDFromHex(user)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "invalid user parameter")
+				return
+			}
+			filter.UserID = &id
+		}
+		if q.Has("limit") {
+			limit, err := positiveInt(q, "limit")
+			if err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			filter.Limit = min(limit, 1000)
+		}
+
+		events, err := api.Audit.ListAudit(r.Context(), filter)
+		if err != nil {
+			api.writeStoreError(w, "get audit events", err)
+			return
+		}
+		if events == nil {
+			events = []models.AuditEvent{}
+		}
+		writeJSON(w, http.StatusOK, events)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
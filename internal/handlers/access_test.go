@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fitness-framework-api/internal/auth"
+	"fitness-framework-api/internal/models"
+)
+
+func TestRequireRole(t *testing.T) {
+	api, _ := newTestAPI(t)
+	identity := func(role models.Role) *auth.Identity {
+		return &auth.Identity{UserID: primitive.NewObjectID(), SessionID: primitive.NewObjectID(), Role: role}
+	}
+
+	tests := []struct {
+		name     string
+		toWrite  bool
+		role     models.Role
+		method   string
+		caller   *auth.Identity
+		status   int
+		identity bool
+	}{
+		{"anonymous", false, models.RoleUser, http.MethodGet, nil, http.StatusUnauthorized, false},
+		{"user on user route", false, models.RoleUser, http.MethodGet, identity(models.RoleUser), http.StatusOK, true},
+		{"user on admin route", false, models.RoleAdmin, http.MethodGet, identity(models.RoleUser), http.StatusForbidden, false},
+		{"coach on admin route", false, models.RoleAdmin, http.MethodGet, identity(models.RoleCoach), http.StatusForbidden, false},
+		{"admin on coach route", false, models.RoleCoach, http.MethodGet, identity(models.RoleAdmin), http.StatusOK, true},
+		{"anonymous read", true, models.RoleAdmin, http.MethodGet, nil, http.StatusOK, false},
+		{"user read", true, models.RoleAdmin, http.MethodGet, identity(models.RoleUser), http.StatusOK, true},
+		{"anonymous write", true, models.RoleAdmin, http.MethodPost, nil, http.StatusUnauthorized, false},
+		{"user write", true, models.RoleAdmin, http.MethodPost, identity(models.RoleUser), http.StatusForbidden, false},
+		{"coach write", true, models.RoleAdmin, http.MethodDelete, identity(models.RoleCoach), http.StatusForbidden, false},
+		{"admin write", true, models.RoleAdmin, http.MethodPost, identity(models.RoleAdmin), http.StatusOK, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *auth.Identity
+			next := func(w http.ResponseWriter, r *http.Request) {
+				if identity, ok := auth.IdentityFromContext(r.Context()); ok {
+					got = &identity
+				}
+				w.WriteHeader(http.StatusOK)
+			}
+			r := newRequest(t, tt.method, "/", nil)
+			if tt.caller != nil {
+				r = withToken(api, r, *tt.caller)
+			}
+			guard := api.RequireRole
+			if tt.toWrite {
+				guard = api.RequireRoleToWrite
+			}
+			rec := serve("/", guard(tt.role, next), r)
+			if rec.Code != tt.status {
+				t.Fatalf("status %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+			if (got != nil) != tt.identity {
+				t.Fatalf("handler got identity %v, want one: %v", got, tt.identity)
+			}
+			if got != nil && (got.UserID != tt.caller.UserID || got.Role != tt.caller.Role) {
+				t.Errorf("handler got identity %+v, want %+v", *got, *tt.caller)
+			}
+		})
+	}
+}
+
+func TestCoachCannotChangeRoles(t *testing.T) {
+	api, s := newTestAPI(t)
+	user := &models.User{Email: "user@example.com", Role: models.RoleUser}
+	if err := s.CreateUser(context.Background(), user); err != nil {
+		t.Fatal(err)
+	}
+	handler := api.RequireRole(models.RoleAdmin, api.UserRoleHandler)
+
+	for _, tt := range []struct {
+		role   models.Role
+		status int
+	}{
+		{models.RoleUser, http.StatusForbidden},
+		{models.RoleCoach, http.StatusForbidden},
+		{models.RoleAdmin, http.StatusOK},
+	} {
+		r := newRequest(t, http.MethodPut, "/api/users/"+user.ID.Hex()+"/role", roleInput{Role: models.RoleCoach})
+		r = withToken(api, r, auth.Identity{UserID: primitive.NewObjectID(), SessionID: primitive.NewObjectID(), Role: tt.role})
+		if rec := serve("/api/users/{id}/role", handler, r); rec.Code != tt.status {
+			t.Errorf("%s: status %d, want %d: %s", tt.role, rec.Code, tt.status, rec.Body)
+		}
+	}
+}
+
+func TestCanModify(t *testing.T) {
+	owner := primitive.NewObjectID()
+	catalog := models.Exercise{Name: "Barbell Squat"}
+	custom := models.Exercise{Name: "My Squat", OwnerID: &owner}
+
+	tests := []struct {
+		name     string
+		caller   auth.Identity
+		exercise models.Exercise
+		want     bool
+	}{
+		{"admin on catalog", auth.Identity{UserID: primitive.NewObjectID(), Role: models.RoleAdmin}, catalog, true},
+		{"coach on catalog", auth.Identity{UserID: primitive.NewObjectID(), Role: models.RoleCoach}, catalog, false},
+		{"user on catalog", auth.Identity{UserID: owner, Role: models.RoleUser}, catalog, false},
+		{"owner", auth.Identity{UserID: owner, Role: models.RoleUser}, custom, true},
+		{"other user", auth.Identity{UserID: primitive.NewObjectID(), Role: models.RoleUser}, custom, false},
+		{"other coach", auth.Identity{UserID: primitive.NewObjectID(), Role: models.RoleCoach}, custom, false},
+		{"admin on custom", auth.Identity{UserID: primitive.NewObjectID(), Role: models.RoleAdmin}, custom, true},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		r := as(newRequest(t, http.MethodPut, "/", nil), tt.caller)
+		if got := canModify(rec, r, tt.exercise); got != tt.want {
+			t.Errorf("%s: canModify = %v, want %v", tt.name, got, tt.want)
+		}
+		if !tt.want && rec.Code != http.StatusForbidden {
+			t.Errorf("%s: status %d, want %d", tt.name, rec.Code, http.StatusForbidden)
+		}
+	}
+}
+
+func TestDeleteExerciseOwnership(t *testing.T) {
+	owner := auth.Identity{UserID: primitive.NewObjectID(), SessionID: primitive.NewObjectID(), Role: models.RoleUser}
+	other := auth.Identity{UserID: primitive.NewObjectID(), SessionID: primitive.NewObjectID(), Role: models.RoleUser}
+	admin := auth.Identity{UserID: primitive.NewObjectID(), SessionID: primitive.NewObjectID(), Role: models.RoleAdmin}
+
+	tests := []struct {
+		name   string
+		caller auth.Identity
+		custom bool
+		status int
+	}{
+		{"user deletes catalog exercise", owner, false, http.StatusForbidden},
+		{"admin deletes catalog exercise", admin, false, http.StatusNoContent},
+		{"owner deletes custom exercise", owner, true, http.StatusNoContent},
+		{"other user deletes custom exercise", other, true, http.StatusNotFound},
+		{"admin deletes custom exercise", admin, true, http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api, s := newTestAPI(t)
+			exercise := &models.Exercise{Name: "Squat", Slug: "squat"}
+			if tt.custom {
+				exercise.OwnerID = &owner.UserID
+			}
+			if err := s.CreateExercise(context.Background(), exercise); err != nil {
+				t.Fatal(err)
+			}
+
+			r := withToken(api, newRequest(t, http.MethodDelete, "/api/exercises/"+exercise.ID.Hex(), nil), tt.caller)
+			rec := serve("/api/exercises/{id}", api.RequireRoleToWrite(models.RoleUser, api.ExerciseHandler), r)
+			if rec.Code != tt.status {
+				t.Fatalf("status %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+			_, err := s.GetExercise(context.Background(), exercise.ID)
+			if deleted := err != nil; deleted != (tt.status == http.StatusNoContent) {
+				t.Errorf("exercise deleted: %v, error %v", deleted, err)
+			}
+		})
+	}
+}
+
+func TestAuditLimit(t *testing.T) {
+	api, _ := newTestAPI(t)
+	admin := auth.Identity{UserID: primitive.NewObjectID(), Role: models.RoleAdmin}
+
+	for _, tt := range []struct {
+		query  string
+		status int
+	}{
+		{"", http.StatusOK},
+		{"?limit=1", http.StatusOK},
+		{"?limit=5000", http.StatusOK},
+		{"?limit=0", http.StatusBadRequest},
+		{"?limit=-5", http.StatusBadRequest},
+	} {
+		r := as(newRequest(t, http.MethodGet, "/api/audit"+tt.query, nil), admin)
+		if rec := serve("/api/audit", api.AuditHandler, r); rec.Code != tt.status {
+			t.Errorf("GET /api/audit%s: status %d, want %d: %s", tt.query, rec.Code, tt.status, rec.Body)
+		}
+	}
+}
+
+func TestAPIKeysRequireSession(t *testing.T) {
+	api, s := newTestAPI(t)
+	user := auth.Identity{UserID: primitive.NewObjectID(), SessionID: primitive.NewObjectID(), Role: models.RoleUser}
+	handler := api.RequireAuth(api.APIKeysHandler)
+
+	r := withToken(api, newRequest(t, http.MethodPost, "/api/users/me/api-keys", apiKeyInput{Name: "ci"}), user)
+	rec := serve("/api/users/me/api-keys", handler, r)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create with access token: status %d, want %d: %s", rec.Code, http.StatusCreated, rec.Body)
+	}
+	key := decodeBody[createdAPIKey](t, rec)
+	if err := s.CreateUser(context.Background(), &models.User{ID: user.UserID, Email: "user@example.com", Role: models.RoleUser}); err != nil {
+		t.Fatal(err)
+	}
+
+	r = newRequest(t, http.MethodPost, "/api/users/me/api-keys", apiKeyInput{Name: "again"})
+	r.Header.Set("Authorization", "Bearer "+key.Key)
+	if rec := serve("/api/users/me/api-keys", handler, r); rec.Code != http.StatusForbidden {
+		t.Errorf("create with API key: status %d, want %d: %s", rec.Code, http.StatusForbidden, rec.Body)
+	}
+
+	r = newRequest(t, http.MethodDelete, "/api/users/me/api-keys/"+key.ID.Hex(), nil)
+	r.Header.Set("Authorization", "Bearer "+key.Key)
+	if rec := serve("/api/users/me/api-keys/{keyId}", api.RequireAuth(api.APIKeyHandler), r); rec.Code != http.StatusForbidden {
+		t.Errorf("revoke with API key: status %d, want %d: %s", rec.Code, http.StatusForbidden, rec.Body)
+	}
+}
@@ -0,0 +1,264 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/mail"
+	"strings"
+	"time"
+
+	"fitness-framework-api/internal/auth"
+	"fitness-framework-api/internal/models"
+	"fitness-framework-api/internal/store"
+)
+
+type credentialsInput struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	Name     string `json:"name,omitempty"`
+}
+
+type refreshInput struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+type tokenResponse struct {
+	User             *models.User `json:"user"`
+	AccessToken      string       `json:"accessToken"`
+	TokenType        string       `json:"tokenType"`
+	ExpiresIn        int          `json:"expiresIn"`
+	RefreshToken     string       `json:"refreshToken"`
+	RefreshExpiresAt time.Time    `json:"refreshExpiresAt"`
+}
+
+func (api *API) RegisterHandler(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w, "POST, OPTIONS")
+
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusOK)
+	case http.MethodPost:
+		api.register(w, r)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (api *API) register(w http.ResponseWriter, r *http.Request) {
+	var input credentialsInput
+	if err := decodeJSON(r, &input); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	email, problems := input.validate()
+	if len(problems) > 0 {
+		writeError(w, http.StatusBadRequest, "validation failed", problems...)
+		return
+	}
+
+	hash, err := auth.HashPassword(input.Password)
+	if err != nil {
+		slog.Error("Error hashing password", "error", err)
+		writeError(w, http.StatusInternalServerError, "failed to create user")
+		return
+	}
+	user := &models.User{
+		Email:        email,
+		Name:         strings.TrimSpace(input.Name),
+		PasswordHash: hash,
+		Role:         models.RoleUser,
+		CreatedAt:    time.Now().UTC(),
+	}
+	if err := api.Users.CreateUser(r.Context(), user); err != nil {
+		if errors.Is(err, store.ErrDuplicate) {
+			writeError(w, http.StatusConflict, "a user with this email already exists")
+			return
+		}
+		api.writeStoreError(w, "create user", err)
+		return
+	}
+
+	api.writeTokens(w, r, http.StatusCreated, user)
+}
+
+func (input credentialsInput) validate() (string, []fieldError) {
+	var problems []fieldError
+	email := strings.ToLower(strings.TrimSpace(input.Email))
+	if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+		problems = append(problems, fieldError{Field: "email", Message: "must be a valid email address"})
+	}
+	switch {
+	case len(input.Password) < auth.MinPasswordLength:
+		problems = append(problems, fieldError{Field: "password", Message: "must be at least 8 characters"})
+	case len(input.Password) > auth.MaxPasswordBytes:
+		problems = append(problems, fieldError{Field: "password", Message: "must be at most 72 bytes"})
+	}
+	return email, problems
+}
+
+func (api *API) LoginHandler(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w, "POST, OPTIONS")
+
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusOK)
+	case http.MethodPost:
+		api.login(w, r)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (api *API) login(w http.ResponseWriter, r *http.Request) {
+	var input credentialsInput
+	if err := decodeJSON(r, &input); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	user, err := api.Users.FindUserByEmail(r.Context(), strings.ToLower(strings.TrimSpace(input.Email)))
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		api.writeStoreError(w, "log in", err)
+		return
+	}
+	hash := ""
+	if user != nil {
+		hash = user.PasswordHash
+	}
+	if !auth.CheckPassword(hash, input.Password) {
+		writeError(w, http.StatusUnauthorized, "invalid email or password")
+		return
+	}
+
+	api.writeTokens(w, r, http.StatusOK, user)
+}
+
+func (api *API) RefreshHandler(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w, "POST, OPTIONS")
+
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusOK)
+	case http.MethodPost:
+		api.refresh(w, r)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// refresh exchanges a refresh token for new tokens. The old refresh token
+// stops working, so a stolen token can be used at most once before the
+// owner notices their session ended.
+func (api *API) refresh(w http.ResponseWriter, r *http.Request) {
+	var input refreshInput
+	if err := decodeJSON(r, &input); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	session, err := api.Users.FindSession(r.Context(), auth.HashToken(input.RefreshToken))
+	if errors.Is(err, store.ErrNotFound) || err == nil && !time.Now().Before(session.ExpiresAt) {
+		writeError(w, http.StatusUnauthorized, "invalid or expired refresh token")
+		return
+	}
+	if err != nil {
+		api.writeStoreError(w, "refresh session", err)
+		return
+	}
+	if err := api.Users.DeleteSession(r.Context(), session.ID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			// A concurrent refresh already used the token.
+			writeError(w, http.StatusUnauthorized, "invalid or expired refresh token")
+			return
+		}
+		api.writeStoreError(w, "refresh session", err)
+		return
+	}
+
+	user, err := api.Users.GetUser(r.Context(), session.UserID)
+	if errors.Is(err, store.ErrNotFound) {
+		writeError(w, http.StatusUnauthorized, "invalid or expired refresh token")
+		return
+	}
+	if err != nil {
+		api.writeStoreError(w, "refresh session", err)
+		return
+	}
+
+	api.writeTokens(w, r, http.StatusOK, user)
+}
+
+// LogoutHandler ends the session of the access token, or every session of
+// the user with ?all=true. Access tokens that were already issued stay
+// valid until they expire.
+func (api *API) LogoutHandler(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w, "POST, OPTIONS")
+
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusOK)
+	case http.MethodPost:
+		identity, _ := auth.IdentityFromContext(r.Context())
+		var err error
+		if r.URL.Query().Get("all") == "true" {
+			err = api.Users.DeleteUserSessions(r.Context(), identity.UserID)
+		} else if identity.SessionID.IsZero() {
+			writeError(w, http.StatusBadRequest, "API keys cannot log out; revoke the key instead")
+			return
+		} else if err = api.Users.DeleteSession(r.Context(), identity.SessionID); errors.Is(err, store.ErrNotFound) {
+			err = nil
+		}
+		if err != nil {
+			api.writeStoreError(w, "log out", err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (api *API) CurrentUserHandler(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w, "GET, OPTIONS")
+
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		identity, _ := auth.IdentityFromContext(r.Context())
+		user, err := api.Users.GetUser(r.Context(), identity.UserID)
+		if err != nil {
+			api.writeStoreError(w, "get user", err)
+			return
+		}
+		writeJSON(w, http.StatusOK, user)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (api *API) writeTokens(w http.ResponseWriter, r *http.Request, status int, user *models.User) {
+	now := time.Now().UTC()
+	refreshToken, hash := auth.NewRefreshToken()
+	session := &models.Session{
+		UserID:    user.ID,
+		TokenHash: hash,
+		CreatedAt: now,
+		ExpiresAt: now.Add(api.Auth.RefreshTTL),
+	}
+	if err := api.Users.CreateSession(r.Context(), session); err != nil {
+		api.writeStoreError(w, "create session", err)
+		return
+	}
+
+	accessToken, expires := api.Auth.AccessToken(auth.Identity{UserID: user.ID, SessionID: session.ID, Role: user.Role}, now)
+	writeJSON(w, status, tokenResponse{
+		User:             user,
+		AccessToken:      accessToken,
+		TokenType:        "Bearer",
+		ExpiresIn:        int(expires.Sub(now).Seconds()),
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: session.ExpiresAt,
+	})
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"fitness-framework-api/internal/auth"
+	"fitness-framework-api/internal/models"
+	"fitness-framework-api/internal/store"
+)
+
+func register(t *testing.T, api *API, email, password string) tokenResponse {
+	t.Helper()
+	rec := serve("/api/auth/register", api.RegisterHandler, newRequest(t, http.MethodPost, "/api/auth/register", credentialsInput{Email: email, Password: password}))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("register %s: status %d: %s", email, rec.Code, rec.Body)
+	}
+	return decodeBody[tokenResponse](t, rec)
+}
+
+func TestRegister(t *testing.T) {
+	api, _ := newTestAPI(t)
+	tokens := register(t, api, " New@Example.com ", "password1")
+	if tokens.User.Email != "new@example.com" || tokens.User.Role != models.RoleUser {
+		t.Errorf("registered %s with role %s, want new@example.com with role user", tokens.User.Email, tokens.User.Role)
+	}
+	identity, err := api.Auth.ParseAccessToken(tokens.AccessToken, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if identity.UserID != tokens.User.ID || identity.SessionID.IsZero() || identity.Role != models.RoleUser {
+		t.Errorf("access token identity %+v does not match user %s", identity, tokens.User.ID.Hex())
+	}
+
+	tests := []struct {
+		name   string
+		input  credentialsInput
+		status int
+	}{
+		{"duplicate email", credentialsInput{Email: "NEW@example.com", Password: "password1"}, http.StatusConflict},
+		{"invalid email", credentialsInput{Email: "not an email", Password: "password1"}, http.StatusBadRequest},
+		{"short password", credentialsInput{Email: "short@example.com", Password: "1234567"}, http.StatusBadRequest},
+		{"password of 72 bytes", credentialsInput{Email: "max@example.com", Password: strings.Repeat("ä", 36)}, http.StatusCreated},
+		{"password over 72 bytes", credentialsInput{Email: "long@example.com", Password: strings.Repeat("ä", 36) + "a"}, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		rec := serve("/api/auth/register", api.RegisterHandler, newRequest(t, http.MethodPost, "/api/auth/register", tt.input))
+		if rec.Code != tt.status {
+			t.Errorf("%s: status %d, want %d: %s", tt.name, rec.Code, tt.status, rec.Body)
+		}
+	}
+}
+
+func TestLogin(t *testing.T) {
+	api, _ := newTestAPI(t)
+	register(t, api, "user@example.com", "password1")
+
+	tests := []struct {
+		name   string
+		input  credentialsInput
+		status int
+	}{
+		{"correct", credentialsInput{Email: "USER@example.com", Password: "password1"}, http.StatusOK},
+		{"wrong password", credentialsInput{Email: "user@example.com", Password: "password2"}, http.StatusUnauthorized},
+		{"unknown email", credentialsInput{Email: "nobody@example.com", Password: "password1"}, http.StatusUnauthorized},
+	}
+	var failures []string
+	for _, tt := range tests {
+		rec := serve("/api/auth/login", api.LoginHandler, newRequest(t, http.MethodPost, "/api/auth/login", tt.input))
+		if rec.Code != tt.status {
+			t.Errorf("%s: status %d, want %d: %s", tt.name, rec.Code, tt.status, rec.Body)
+		}
+		if rec.Code == http.StatusUnauthorized {
+			failures = append(failures, rec.Body.String())
+		}
+	}
+	// Unknown emails must not be told apart from wrong passwords.
+	if len(failures) == 2 && failures[0] != failures[1] {
+		t.Errorf("wrong password returns %q but unknown email %q", failures[0], failures[1])
+	}
+}
+
+func TestRefreshRotatesSession(t *testing.T) {
+	api, s := newTestAPI(t)
+	first := register(t, api, "user@example.com", "password1")
+
+	refresh := func(token string) *tokenResponse {
+		rec := serve("/api/auth/refresh", api.RefreshHandler, newRequest(t, http.MethodPost, "/api/auth/refresh", refreshInput{RefreshToken: token}))
+		if rec.Code != http.StatusOK {
+			return nil
+		}
+		tokens := decodeBody[tokenResponse](t, rec)
+		return &tokens
+	}
+
+	second := refresh(first.RefreshToken)
+	if second == nil {
+		t.Fatal("refresh with a new refresh token failed")
+	}
+	if second.RefreshToken == first.RefreshToken {
+		t.Error("refresh returned the same refresh token")
+	}
+	if _, err := s.FindSession(context.Background(), auth.HashToken(first.RefreshToken)); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("old session is still stored: %v", err)
+	}
+	if refresh(first.RefreshToken) != nil {
+		t.Error("a used refresh token worked again")
+	}
+	if refresh("not a token") != nil {
+		t.Error("an unknown refresh token worked")
+	}
+	if refresh(second.RefreshToken) == nil {
+		t.Error("the rotated refresh token does not work")
+	}
+}
+
+func TestRefreshRejectsExpiredSession(t *testing.T) {
+	api, s := newTestAPI(t)
+	tokens := register(t, api, "user@example.com", "password1")
+	token, hash := auth.NewRefreshToken()
+	session := &models.Session{UserID: tokens.User.ID, TokenHash: hash, ExpiresAt: time.Now().Add(-time.Minute)}
+	if err := s.CreateSession(context.Background(), session); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := serve("/api/auth/refresh", api.RefreshHandler, newRequest(t, http.MethodPost, "/api/auth/refresh", refreshInput{RefreshToken: token}))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status %d, want %d: %s", rec.Code, http.StatusUnauthorized, rec.Body)
+	}
+}
+
+func TestLogoutEndsSession(t *testing.T) {
+	api, _ := newTestAPI(t)
+	tokens := register(t, api, "user@example.com", "password1")
+
+	r := newRequest(t, http.MethodPost, "/api/auth/logout", nil)
+	r.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+	if rec := serve("/api/auth/logout", api.RequireAuth(api.LogoutHandler), r); rec.Code != http.StatusNoContent {
+		t.Fatalf("logout: status %d, want %d: %s", rec.Code, http.StatusNoContent, rec.Body)
+	}
+
+	rec := serve("/api/auth/refresh", api.RefreshHandler, newRequest(t, http.MethodPost, "/api/auth/refresh", refreshInput{RefreshToken: tokens.RefreshToken}))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("refresh after logout: status %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"net/http"
+
+	"fitness-framework-api/internal/auth"
+)
+
+// CustomExercisesHandler lists and creates the custom exercises of the
+// current user.
+func (api *API) CustomExercisesHandler(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w, "GET, POST, OPTIONS")
+
+	identity, _ := auth.IdentityFromContext(r.Context())
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		r = r.Clone(r.Context())
+		q := r.URL.Query()
+		q.Set("custom", "true")
+		q.Del("owner")
+		r.URL.RawQuery = q.Encode()
+		api.listExercises(w, r)
+	case http.MethodPost:
+		api.createExercise(w, r, &identity.UserID)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// PromoteExerciseHandler moves a custom exercise into the shared catalog.
+func (api *API) PromoteExerciseHandler(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w, "POST, OPTIONS")
+
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusOK)
+		return
+	case http.MethodPost:
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	existing, ok := api.lookupExercise(w, r)
+	if !ok {
+		return
+	}
+	if existing.OwnerID == nil {
+		writeError(w, http.StatusConflict, "exercise is already part of the catalog")
+		return
+	}
+	if existing.VariationOf != nil {
+		parent, err := api.Store.GetExercise(r.Context(), *existing.VariationOf)
+		if err != nil {
+			api.writeStoreError(w, "get exercise", err)
+			return
+		}
+		if parent.OwnerID != nil {
+			writeError(w, http.StatusConflict, "exercise is a variation of the custom exercise "+parent.Name+", promote that first")
+			return
+		}
+	}
+
+	exercise := *existing
+	exercise.OwnerID = nil
+	if !api.validateAndReport(w, r.Context(), &exercise) {
+		return
+	}
+	if err := api.Store.UpdateExercise(r.Context(), exercise); err != nil {
+		api.writeStoreError(w, "update exercise", err)
+		return
+	}
+	api.audit(r, "exercise.promote", "exercise", exercise.ID.Hex(), exercise.Name)
+
+	promoted, err := api.Store.GetExercise(r.Context(), exercise.ID)
+	if err != nil {
+		api.writeStoreError(w, "get exercise", err)
+		return
+	}
+	api.setMediaURLs(promoted)
+	writeJSON(w, http.StatusOK, promoted)
+}
@@ -0,0 +1,569 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fitness-framework-api/internal/auth"
+	"fitness-framework-api/internal/constants"
+	"fitness-framework-api/internal/models"
+	"fitness-framework-api/internal/slug"
+	"fitness-framework-api/internal/store"
+)
+
+type exerciseInput struct {
+	Name         string                `json:"name"`
+	Aliases      []string              `json:"aliases"`
+	VariationOf  *primitive.ObjectID   `json:"variationOf"`
+	Equipment    []string              `json:"equipment"`
+	EquipmentIDs *[]primitive.ObjectID `json:"equipmentIds"`
+	Muscles      []string              `json:"muscles"`
+	MuscleIDs    *[]primitive.ObjectID `json:"muscleIds"`
+	// MuscleTargets replaces Muscles and MuscleIDs when present. Without it,
+	// muscles that stay on the exercise keep their current role.
+	MuscleTargets *[]models.MuscleTarget `json:"muscleTargets"`
+
+	Mechanics          string `json:"mechanics"`
+	Force              string `json:"force"`
+	MovementPattern    string `json:"movementPattern"`
+	Laterality         string `json:"laterality"`
+	Difficulty         string `json:"difficulty"`
+	BodyweightLoadable *bool  `json:"bodyweightLoadable"`
+
+	Description    string   `json:"description"`
+	Instructions   []string `json:"instructions"`
+	Cues           []string `json:"cues"`
+	CommonMistakes []string `json:"commonMistakes"`
+	SafetyNotes    []string `json:"safetyNotes"`
+}
+
+func inputFromExercise(ex models.Exercise) exerciseInput {
+	return exerciseInput{
+		Name:               ex.Name,
+		Aliases:            ex.Aliases,
+		VariationOf:        ex.VariationOf,
+		Equipment:          ex.Equipment,
+		Muscles:            ex.Muscles,
+		Mechanics:          ex.Mechanics,
+		Force:              ex.Force,
+		MovementPattern:    ex.MovementPattern,
+		Laterality:         ex.Laterality,
+		Difficulty:         ex.Difficulty,
+		BodyweightLoadable: ex.BodyweightLoadable,
+		Description:        ex.Description,
+		Instructions:       ex.Instructions,
+		Cues:               ex.Cues,
+		CommonMistakes:     ex.CommonMistakes,
+		SafetyNotes:        ex.SafetyNotes,
+	}
+}
+
+func (in exerciseInput) apply(ex *models.Exercise) {
+	ex.Name = strings.TrimSpace(in.Name)
+	ex.Aliases = trimAll(in.Aliases)
+	ex.VariationOf = in.VariationOf
+	ex.Equipment = in.Equipment
+	ex.EquipmentIDs = nil
+	if in.EquipmentIDs != nil {
+		ex.EquipmentIDs = *in.EquipmentIDs
+	}
+	ex.Muscles = in.Muscles
+	ex.MuscleIDs = nil
+	if in.MuscleIDs != nil {
+		ex.MuscleIDs = *in.MuscleIDs
+	}
+	if in.MuscleTargets != nil {
+		ex.MuscleTargets = *in.MuscleTargets
+		ex.Muscles, ex.MuscleIDs = nil, nil
+	}
+	ex.Mechanics = strings.ToLower(strings.TrimSpace(in.Mechanics))
+	ex.Force = strings.ToLower(strings.TrimSpace(in.Force))
+	ex.MovementPattern = strings.ToLower(strings.TrimSpace(in.MovementPattern))
+	ex.Laterality = strings.ToLower(strings.TrimSpace(in.Laterality))
+	ex.Difficulty = strings.ToLower(strings.TrimSpace(in.Difficulty))
+	ex.BodyweightLoadable = in.BodyweightLoadable
+	ex.Description = strings.TrimSpace(in.Description)
+	ex.Instructions = trimAll(in.Instructions)
+	ex.Cues = trimAll(in.Cues)
+	ex.CommonMistakes = trimAll(in.CommonMistakes)
+	ex.SafetyNotes = trimAll(in.SafetyNotes)
+}
+
+func trimAll(values []string) []string {
+	if values == nil {
+		return nil
+	}
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = strings.TrimSpace(v)
+	}
+	return out
+}
+
+func (api *API) ExerciseHandler(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w, "GET, PUT, PATCH, DELETE, OPTIONS")
+
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		api.getExercise(w, r)
+	case http.MethodPut:
+		api.updateExercise(w, r, false)
+	case http.MethodPatch:
+		api.updateExercise(w, r, true)
+	case http.MethodDelete:
+		api.deleteExercise(w, r)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (api *API) getExercise(w http.ResponseWriter, r *http.Request) {
+	view, err := parseView(r.URL.Query(), viewFull)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	localizer, ok := api.localizer(w, r)
+	if !ok {
+		return
+	}
+
+	exercise, ok := api.lookupExercise(w, r)
+	if !ok {
+		return
+	}
+
+	localizer.Exercise(exercise)
+	applyView(exercise, view)
+	api.setMediaURLs(exercise)
+	writeJSON(w, http.StatusOK, exercise)
+}
+
+// createExercise adds an exercise to the shared catalog, or a custom
+// exercise of owner if owner is not nil.
+func (api *API) createExercise(w http.ResponseWriter, r *http.Request, owner *primitive.ObjectID) {
+	var in exerciseInput
+	if err := decodeJSON(r, &in); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	exercise := models.Exercise{OwnerID: owner}
+	in.apply(&exercise)
+	if !api.validateAndReport(w, r.Context(), &exercise) {
+		return
+	}
+
+	if err := api.Store.CreateExercise(r.Context(), &exercise); err != nil {
+		api.writeStoreError(w, "create exercise", err)
+		return
+	}
+	api.auditExercise(r, "exercise.create", exercise)
+
+	w.Header().Set("Location", "/api/exercises/"+exercise.ID.Hex())
+	writeJSON(w, http.StatusCreated, exercise)
+}
+
+func (api *API) updateExercise(w http.ResponseWriter, r *http.Request, partial bool) {
+	existing, ok := api.lookupExercise(w, r)
+	if !ok || !canModify(w, r, *existing) {
+		return
+	}
+
+	var in exerciseInput
+	if partial {
+		in = inputFromExercise(*existing)
+	}
+	if err := decodeJSON(r, &in); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	exercise := *existing
+	in.apply(&exercise)
+	if !api.validateAndReport(w, r.Context(), &exercise) {
+		return
+	}
+
+	if err := api.Store.UpdateExercise(r.Context(), exercise); err != nil {
+		api.writeStoreError(w, "update exercise", err)
+		return
+	}
+	api.auditExercise(r, "exercise.update", exercise)
+
+	updated, err := api.Store.GetExercise(r.Context(), exercise.ID)
+	if err != nil {
+		api.writeStoreError(w, "get exercise", err)
+		return
+	}
+
+	api.setMediaURLs(updated)
+	writeJSON(w, http.StatusOK, updated)
+}
+
+func (api *API) deleteExercise(w http.ResponseWriter, r *http.Request) {
+	existing, ok := api.lookupExercise(w, r)
+	if !ok || !canModify(w, r, *existing) {
+		return
+	}
+
+	variations, err := api.variationsOf(r.Context(), existing.ID)
+	if err != nil {
+		api.writeStoreError(w, "get exercises", err)
+		return
+	}
+	for _, variation := range variations {
+		variation.VariationOf = existing.VariationOf
+		if err := api.Store.UpdateExercise(r.Context(), variation); err != nil {
+			api.writeStoreError(w, "update exercise", err)
+			return
+		}
+	}
+
+	if err := api.Store.DeleteExercise(r.Context(), existing.ID); err != nil {
+		api.writeStoreError(w, "delete exercise", err)
+		return
+	}
+	api.deleteMediaFiles(r.Context(), existing.Media)
+	api.auditExercise(r, "exercise.delete", *existing)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// lookupExercise finds the exercise of the id path value, by ID or slug.
+// Custom exercises are only found for their owner and admins.
+func (api *API) lookupExercise(w http.ResponseWriter, r *http.Request) (*models.Exercise, bool) {
+	identity, authenticated := auth.IdentityFromContext(r.Context())
+	ref := r.PathValue("id")
+
+	var exercise *models.Exercise
+	var err error
+	if id, idErr := primitive.ObjectIDFromHex(ref); idErr == nil {
+		exercise, err = api.Store.GetExercise(r.Context(), id)
+		if err == nil && exercise.OwnerID != nil && *exercise.OwnerID != identity.UserID && !identity.Role.Includes(models.RoleAdmin) {
+			err = store.ErrNotFound
+		}
+	} else {
+		exercise, err = api.Store.GetExerciseBySlug(r.Context(), ref)
+		if errors.Is(err, store.ErrNotFound) && authenticated {
+			exercise, err = api.customExerciseBySlug(r.Context(), identity.UserID, ref)
+		}
+	}
+	if err != nil {
+		api.writeStoreError(w, "get exercise", err)
+		return nil, false
+	}
+	return exercise, true
+}
+
+func (api *API) customExerciseBySlug(ctx context.Context, owner primitive.ObjectID, exerciseSlug string) (*models.Exercise, error) {
+	custom := true
+	exercises, err := api.Store.GetExercises(ctx, store.ExerciseFilter{Owner: &owner, Custom: &custom})
+	if err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(exercises, func(ex models.Exercise) bool { return ex.Slug == exerciseSlug })
+	if i < 0 {
+		return nil, store.ErrNotFound
+	}
+	return &exercises[i], nil
+}
+
+// canModify reports whether the caller may change exercise: admins may
+// change every exercise, users their own custom exercises. Otherwise it
+// writes an error response.
+func canModify(w http.ResponseWriter, r *http.Request, exercise models.Exercise) bool {
+	identity, _ := auth.IdentityFromContext(r.Context())
+	if identity.Role.Includes(models.RoleAdmin) || exercise.OwnerID != nil && *exercise.OwnerID == identity.UserID {
+		return true
+	}
+	writeError(w, http.StatusForbidden, fmt.Sprintf("this action requires the %s role", models.RoleAdmin))
+	return false
+}
+
+// auditExercise records changes to the shared catalog. Custom exercises
+// are private to their owner and not audited.
+func (api *API) auditExercise(r *http.Request, action string, exercise models.Exercise) {
+	if exercise.OwnerID == nil {
+		api.audit(r, action, "exercise", exercise.ID.Hex(), exercise.Name)
+	}
+}
+
+func (api *API) variationsOf(ctx context.Context, id primitive.ObjectID) ([]models.Exercise, error) {
+	exercises, err := api.Store.GetExercises(ctx, store.ExerciseFilter{AllOwners: true})
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(exercises, func(ex models.Exercise) bool {
+		return ex.VariationOf == nil || *ex.VariationOf != id
+	}), nil
+}
+
+func (api *API) writeStoreError(w http.ResponseWriter, action string, err error) {
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		writeError(w, http.StatusNotFound, "not found")
+	case errors.Is(err, store.ErrDuplicate):
+		writeError(w, http.StatusConflict, "a record with the same name already exists")
+	case errors.Is(err, store.ErrUnknownOption):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		slog.Error("Store operation failed", "action", action, "error", err)
+		writeError(w, http.StatusInternalServerError, "failed to "+action)
+	}
+}
+
+func (api *API) validateAndReport(w http.ResponseWriter, ctx context.Context, exercise *models.Exercise) bool {
+	problems, err := api.validateExercise(ctx, exercise)
+	if err != nil {
+		slog.Error("Error validating exercise", "error", err)
+		writeError(w, http.StatusInternalServerError, "failed to validate exercise")
+		return false
+	}
+	if len(problems) > 0 {
+		writeError(w, http.StatusBadRequest, "validation failed", problems...)
+		return false
+	}
+	return true
+}
+
+func (api *API) validateExercise(ctx context.Context, exercise *models.Exercise) ([]fieldError, error) {
+	var problems []fieldError
+
+	if exercise.Name == "" {
+		problems = append(problems, fieldError{Field: "name", Message: "name is required"})
+	} else {
+		other, err := api.Store.FindExerciseByName(ctx, exercise.Name)
+		if err != nil && !errors.Is(err, store.ErrNotFound) {
+			return nil, err
+		}
+		if other != nil && other.ID != exercise.ID {
+			problems = append(problems, fieldError{Field: "name", Message: fmt.Sprintf("an exercise named %q already exists", other.Name)})
+		} else if exerciseSlug := slug.Make(exercise.Name); exerciseSlug == "" {
+			problems = append(problems, fieldError{Field: "name", Message: "name must contain at least one letter or digit"})
+		} else {
+			other, err = api.Store.GetExerciseBySlug(ctx, exerciseSlug)
+			if err != nil && !errors.Is(err, store.ErrNotFound) {
+				return nil, err
+			}
+			if other != nil && other.ID != exercise.ID {
+				problems = append(problems, fieldError{Field: "name", Message: fmt.Sprintf("name has the same slug %q as exercise %q", exerciseSlug, other.Name)})
+			} else if exercise.OwnerID != nil {
+				other, err = api.customExerciseBySlug(ctx, *exercise.OwnerID, exerciseSlug)
+				if err != nil && !errors.Is(err, store.ErrNotFound) {
+					return nil, err
+				}
+				if other != nil && other.ID != exercise.ID {
+					problems = append(problems, fieldError{Field: "name", Message: fmt.Sprintf("you already have an exercise named %q", other.Name)})
+				}
+			}
+		}
+	}
+
+	for _, ref := range []struct {
+		kind  store.OptionKind
+		field string
+		names *[]string
+		ids   []primitive.ObjectID
+	}{
+		{store.OptionEquipment, "equipment", &exercise.Equipment, exercise.EquipmentIDs},
+		{store.OptionMuscle, "muscles", &exercise.Muscles, exercise.MuscleIDs},
+	} {
+		registered, err := api.Store.GetOptions(ctx, ref.kind)
+		if err != nil {
+			return nil, err
+		}
+
+		if ref.kind == store.OptionMuscle && exercise.Muscles == nil && exercise.MuscleIDs == nil && len(exercise.MuscleTargets) > 0 {
+			var targetProblems []fieldError
+			exercise.Muscles, targetProblems = resolveMuscleTargets(exercise.MuscleTargets, registered)
+			problems = append(problems, targetProblems...)
+		}
+
+		if ref.ids != nil {
+			var idProblems []fieldError
+			*ref.names, idProblems = optionNamesForIDs(ref.field, ref.ids, registered)
+			problems = append(problems, idProblems...)
+		}
+
+		var optionProblems []fieldError
+		*ref.names, optionProblems = canonicalizeOptions(ref.field, *ref.names, registered)
+		problems = append(problems, optionProblems...)
+	}
+
+	for _, attr := range []struct {
+		field  string
+		value  string
+		valid  func(string) bool
+		values []string
+	}{
+		{"mechanics", exercise.Mechanics, constants.IsValidMechanics, constants.AllMechanics},
+		{"force", exercise.Force, constants.IsValidForce, constants.AllForces},
+		{"movementPattern", exercise.MovementPattern, constants.IsValidMovementPattern, constants.AllMovementPatterns},
+		{"laterality", exercise.Laterality, constants.IsValidLaterality, constants.AllLateralities},
+		{"difficulty", exercise.Difficulty, constants.IsValidDifficulty, constants.AllDifficulties},
+	} {
+		if attr.value != "" && !attr.valid(attr.value) {
+			problems = append(problems, fieldError{Field: attr.field, Message: fmt.Sprintf("must be one of %s", strings.Join(attr.values, ", "))})
+		}
+	}
+
+	visited := map[primitive.ObjectID]bool{exercise.ID: true}
+	for parentID := exercise.VariationOf; parentID != nil; {
+		if visited[*parentID] {
+			problems = append(problems, fieldError{Field: "variationOf", Message: "an exercise cannot be a variation of itself or of its own variations"})
+			break
+		}
+		visited[*parentID] = true
+		parent, err := api.Store.GetExercise(ctx, *parentID)
+		if errors.Is(err, store.ErrNotFound) {
+			if parentID == exercise.VariationOf {
+				problems = append(problems, fieldError{Field: "variationOf", Message: fmt.Sprintf("%q is not an exercise id", parentID.Hex())})
+			}
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if parentID == exercise.VariationOf && parent.OwnerID != nil && (exercise.OwnerID == nil || *parent.OwnerID != *exercise.OwnerID) {
+			message := fmt.Sprintf("%q is not an exercise id", parentID.Hex())
+			if exercise.OwnerID == nil {
+				message = "an exercise of the shared catalog cannot be a variation of a custom exercise"
+			}
+			problems = append(problems, fieldError{Field: "variationOf", Message: message})
+			break
+		}
+		parentID = parent.VariationOf
+	}
+
+	for i, alias := range exercise.Aliases {
+		field := fmt.Sprintf("aliases[%d]", i)
+		switch {
+		case alias == "":
+			problems = append(problems, fieldError{Field: field, Message: "must not be empty"})
+		case strings.EqualFold(alias, exercise.Name):
+			problems = append(problems, fieldError{Field: field, Message: "must differ from the name"})
+		case slices.IndexFunc(exercise.Aliases[:i], func(a string) bool { return strings.EqualFold(a, alias) }) >= 0:
+			problems = append(problems, fieldError{Field: field, Message: fmt.Sprintf("%q is listed more than once", alias)})
+		}
+	}
+
+	for _, text := range []struct {
+		field string
+		lines []string
+	}{
+		{"instructions", exercise.Instructions},
+		{"cues", exercise.Cues},
+		{"commonMistakes", exercise.CommonMistakes},
+		{"safetyNotes", exercise.SafetyNotes},
+	} {
+		for i, line := range text.lines {
+			if line == "" {
+				problems = append(problems, fieldError{Field: fmt.Sprintf("%s[%d]", text.field, i), Message: "must not be empty"})
+			}
+		}
+	}
+
+	return problems, nil
+}
+
+func resolveMuscleTargets(targets []models.MuscleTarget, registered []models.Option) ([]string, []fieldError) {
+	var problems []fieldError
+	names := make([]string, 0, len(targets))
+	for i := range targets {
+		target := &targets[i]
+		field := fmt.Sprintf("muscleTargets[%d]", i)
+
+		j := slices.IndexFunc(registered, func(o models.Option) bool {
+			if !target.MuscleID.IsZero() {
+				return o.ID == target.MuscleID
+			}
+			return strings.EqualFold(o.Name, strings.TrimSpace(target.Muscle))
+		})
+		switch {
+		case target.MuscleID.IsZero() && strings.TrimSpace(target.Muscle) == "":
+			problems = append(problems, fieldError{Field: field + ".muscle", Message: "muscle or muscleId is required"})
+		case j < 0 && !target.MuscleID.IsZero():
+			problems = append(problems, fieldError{Field: field + ".muscleId", Message: fmt.Sprintf("%q is not a registered option id", target.MuscleID.Hex())})
+		case j < 0:
+			problems = append(problems, fieldError{Field: field + ".muscle", Message: fmt.Sprintf("%q is not a registered option", target.Muscle)})
+		default:
+			target.Muscle, target.MuscleID = registered[j].Name, registered[j].ID
+			names = append(names, target.Muscle)
+		}
+
+		if target.Role == "" {
+			target.Role = constants.MuscleRolePrimary
+		}
+		if !constants.IsValidMuscleRole(target.Role) {
+			problems = append(problems, fieldError{Field: field + ".role", Message: fmt.Sprintf("role must be one of %s", strings.Join(constants.AllMuscleRoles, ", "))})
+		}
+		target.Role = strings.ToLower(target.Role)
+		if target.Weight < 0 || target.Weight > 1 {
+			problems = append(problems, fieldError{Field: field + ".weight", Message: "weight must be between 0 and 1"})
+		}
+	}
+	return names, problems
+}
+
+func optionNamesForIDs(field string, ids []primitive.ObjectID, registered []models.Option) ([]string, []fieldError) {
+	var problems []fieldError
+	names := make([]string, 0, len(ids))
+	for i, id := range ids {
+		j := slices.IndexFunc(registered, func(o models.Option) bool { return o.ID == id })
+		if j < 0 {
+			problems = append(problems, fieldError{Field: fmt.Sprintf("%sIds[%d]", strings.TrimSuffix(field, "s"), i), Message: fmt.Sprintf("%q is not a registered option id", id.Hex())})
+			continue
+		}
+		names = append(names, registered[j].Name)
+	}
+	return names, problems
+}
+
+func canonicalizeOptions(field string, values []string, registered []models.Option) ([]string, []fieldError) {
+	if len(values) == 0 {
+		return values, []fieldError{{Field: field, Message: "at least one value is required"}}
+	}
+
+	var problems []fieldError
+	canonical := make([]string, 0, len(values))
+	for i, value := range values {
+		match := ""
+		for _, option := range registered {
+			if strings.EqualFold(option.Name, strings.TrimSpace(value)) {
+				match = option.Name
+				break
+			}
+		}
+
+		fieldName := fmt.Sprintf("%s[%d]", field, i)
+		switch {
+		case match == "":
+			problems = append(problems, fieldError{Field: fieldName, Message: fmt.Sprintf("%q is not a registered option", value)})
+		case containsFold(canonical, match):
+			problems = append(problems, fieldError{Field: fieldName, Message: fmt.Sprintf("%q is listed more than once", value)})
+		default:
+			canonical = append(canonical, match)
+		}
+	}
+	return canonical, problems
+}
+
+func containsFold(values []string, target string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, target) {
+			return true
+		}
+	}
+	return false
+}
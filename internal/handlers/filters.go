@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"fitness-framework-api/internal/constants"
+	"fitness-framework-api/internal/store"
+)
+
+func parseExerciseFilter(q url.Values) (store.ExerciseFilter, error) {
+	filter := store.ExerciseFilter{Query: strings.TrimSpace(q.Get("q"))}
+	var err error
+
+	if filter.Equipment, err = parseSetFilter(q, "equipment"); err != nil {
+		return filter, err
+	}
+	if filter.Equipment.Mode == store.MatchSubset {
+		filter.Equipment.Values = append(filter.Equipment.Values, constants.EquipmentNone)
+	}
+	if filter.Muscles, err = parseSetFilter(q, "muscles"); err != nil {
+		return filter, err
+	}
+	if filter.MuscleRoles, err = parseEnumFilter(q, "muscles_role", constants.IsValidMuscleRole); err != nil {
+		return filter, err
+	}
+
+	if filter.Mechanics, err = parseEnumFilter(q, "mechanics", constants.IsValidMechanics); err != nil {
+		return filter, err
+	}
+	if filter.Force, err = parseEnumFilter(q, "force", constants.IsValidForce); err != nil {
+		return filter, err
+	}
+	if filter.MovementPatterns, err = parseEnumFilter(q, "movement_pattern", constants.IsValidMovementPattern); err != nil {
+		return filter, err
+	}
+	if filter.Laterality, err = parseEnumFilter(q, "laterality", constants.IsValidLaterality); err != nil {
+		return filter, err
+	}
+	if filter.Difficulty, err = parseEnumFilter(q, "difficulty", constants.IsValidDifficulty); err != nil {
+		return filter, err
+	}
+	if maxDifficulty := q.Get("difficulty_max"); maxDifficulty != "" {
+		if !constants.IsValidDifficulty(maxDifficulty) {
+			return filter, fmt.Errorf("invalid difficulty_max parameter: unsupported value %q", maxDifficulty)
+		}
+		allowed := constants.AllDifficulties[:slices.Index(constants.AllDifficulties, strings.ToLower(maxDifficulty))+1]
+		if len(filter.Difficulty) == 0 {
+			filter.Difficulty = slices.Clone(allowed)
+		} else {
+			filter.Difficulty = slices.DeleteFunc(filter.Difficulty, func(d string) bool { return !slices.Contains(allowed, d) })
+			if len(filter.Difficulty) == 0 {
+				return filter, fmt.Errorf("difficulty and difficulty_max parameters exclude every level")
+			}
+		}
+	}
+	if v := q.Get("custom"); v != "" {
+		custom, err := strconv.ParseBool(v)
+		if err != nil {
+			return filter, fmt.Errorf("invalid custom parameter: %q is not a boolean", v)
+		}
+		filter.Custom = &custom
+	}
+	if v := q.Get("bodyweight_loadable"); v != "" {
+		loadable, err := strconv.ParseBool(v)
+		if err != nil {
+			return filter, fmt.Errorf("invalid bodyweight_loadable parameter: %q is not a boolean", v)
+		}
+		filter.BodyweightLoadable = &loadable
+	}
+	return filter, nil
+}
+
+func parseEnumFilter(q url.Values, name string, valid func(string) bool) ([]string, error) {
+	var values []string
+	for _, v := range q[name] {
+		if !valid(v) {
+			return nil, fmt.Errorf("invalid %s parameter: unsupported value %q", name, v)
+		}
+		values = append(values, strings.ToLower(v))
+	}
+	return values, nil
+}
+
+func parseSetFilter(q url.Values, name string) (store.SetFilter, error) {
+	mode, err := store.ParseMatchMode(q.Get(name + "_match"))
+	if err != nil {
+		return store.SetFilter{}, fmt.Errorf("invalid %s_match parameter: %w", name, err)
+	}
+	return store.SetFilter{
+		Values:  q[name],
+		Mode:    mode,
+		Exclude: q[name+"_not"],
+	}, nil
+}
@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fitness-framework-api/internal/auth"
+	"fitness-framework-api/internal/models"
+	"fitness-framework-api/internal/slug"
+	"fitness-framework-api/internal/store"
+)
+
+type equipmentProfileInput struct {
+	Name      string   `json:"name"`
+	Equipment []string `json:"equipment"`
+}
+
+func inputFromProfile(profile models.EquipmentProfile) equipmentProfileInput {
+	return equipmentProfileInput{Name: profile.Name, Equipment: profile.Equipment}
+}
+
+func (api *API) GymsHandler(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w, "GET, POST, OPTIONS")
+
+	identity, _ := auth.IdentityFromContext(r.Context())
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		profiles, ok := api.equipmentProfiles(w, r.Context(), identity.UserID)
+		if !ok {
+			return
+		}
+		if profiles == nil {
+			profiles = []models.EquipmentProfile{}
+		}
+		writeJSON(w, http.StatusOK, profiles)
+	case http.MethodPost:
+		var in equipmentProfileInput
+		if err := decodeJSON(r, &in); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+			return
+		}
+		now := time.Now().UTC()
+		profile := models.EquipmentProfile{UserID: identity.UserID, CreatedAt: now, UpdatedAt: now}
+		if !api.applyProfileInput(w, r.Context(), in, &profile) {
+			return
+		}
+		if err := api.Profiles.CreateEquipmentProfile(r.Context(), &profile); err != nil {
+			api.writeProfileError(w, "create equipment profile", err)
+			return
+		}
+		w.Header().Set("Location", "/api/users/me/gyms/"+profile.Slug)
+		writeJSON(w, http.StatusCreated, profile)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (api *API) GymHandler(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w, "GET, PUT, PATCH, DELETE, OPTIONS")
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	identity, _ := auth.IdentityFromContext(r.Context())
+	current, ok := api.lookupGym(w, r.Context(), identity.UserID, r.PathValue("gym"))
+	if !ok {
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, current)
+	case http.MethodPut, http.MethodPatch:
+		var in equipmentProfileInput
+		if r.Method == http.MethodPatch {
+			in = inputFromProfile(current)
+		}
+		if err := decodeJSON(r, &in); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+			return
+		}
+		profile := current
+		profile.UpdatedAt = time.Now().UTC()
+		if !api.applyProfileInput(w, r.Context(), in, &profile) {
+			return
+		}
+		if err := api.Profiles.UpdateEquipmentProfile(r.Context(), profile); err != nil {
+			api.writeProfileError(w, "update equipment profile", err)
+			return
+		}
+		profile.Slug = slug.Make(profile.Name)
+		writeJSON(w, http.StatusOK, profile)
+	case http.MethodDelete:
+		if err := api.Profiles.DeleteEquipmentProfile(r.Context(), identity.UserID, current.ID); err != nil {
+			api.writeStoreError(w, "delete equipment profile", err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (api *API) applyProfileInput(w http.ResponseWriter, ctx context.Context, in equipmentProfileInput, profile *models.EquipmentProfile) bool {
+	var problems []fieldError
+	profile.Name = strings.TrimSpace(in.Name)
+	if slug.Make(profile.Name) == "" {
+		problems = append(problems, fieldError{Field: "name", Message: "name must contain a letter or digit"})
+	}
+
+	registered, err := api.Store.GetOptions(ctx, store.OptionEquipment)
+	if err != nil {
+		api.writeStoreError(w, "get options", err)
+		return false
+	}
+	names, ids, err := store.ResolveOptions(in.Equipment, registered)
+	if err != nil {
+		problems = append(problems, fieldError{Field: "equipment", Message: err.Error()})
+	}
+	if len(problems) > 0 {
+		writeError(w, http.StatusBadRequest, "validation failed", problems...)
+		return false
+	}
+
+	profile.Equipment, profile.EquipmentIDs = []string{}, []primitive.ObjectID{}
+	for i, id := range ids {
+		if !slices.Contains(profile.EquipmentIDs, id) {
+			profile.Equipment = append(profile.Equipment, names[i])
+			profile.EquipmentIDs = append(profile.EquipmentIDs, id)
+		}
+	}
+	profile.Equipment, profile.EquipmentIDs = store.SortRefs(profile.Equipment, profile.EquipmentIDs)
+	return true
+}
+
+func (api *API) writeProfileError(w http.ResponseWriter, action string, err error) {
+	if errors.Is(err, store.ErrDuplicate) {
+		writeError(w, http.StatusConflict, "you already have an equipment profile with this name")
+		return
+	}
+	api.writeStoreError(w, action, err)
+}
+
+// equipmentProfiles returns the profiles of a user with the current names
+// of their equipment. Equipment options deleted since are left out.
+func (api *API) equipmentProfiles(w http.ResponseWriter, ctx context.Context, userID primitive.ObjectID) ([]models.EquipmentProfile, bool) {
+	profiles, err := api.Profiles.GetEquipmentProfiles(ctx, userID)
+	if err != nil {
+		api.writeStoreError(w, "get equipment profiles", err)
+		return nil, false
+	}
+	registered, err := api.Store.GetOptions(ctx, store.OptionEquipment)
+	if err != nil {
+		api.writeStoreError(w, "get options", err)
+		return nil, false
+	}
+
+	for i := range profiles {
+		profile := &profiles[i]
+		profile.Equipment = []string{}
+		ids := []primitive.ObjectID{}
+		for _, id := range profile.EquipmentIDs {
+			if j := slices.IndexFunc(registered, func(o models.Option) bool { return o.ID == id }); j >= 0 {
+				profile.Equipment = append(profile.Equipment, registered[j].Name)
+				ids = append(ids, id)
+			}
+		}
+		profile.EquipmentIDs = ids
+		profile.Equipment, profile.EquipmentIDs = store.SortRefs(profile.Equipment, profile.EquipmentIDs)
+	}
+	return profiles, true
+}
+
+// lookupGym finds a profile of the user by ID, or by a name or slug.
+func (api *API) lookupGym(w http.ResponseWriter, ctx context.Context, userID primitive.ObjectID, ref string) (models.EquipmentProfile, bool) {
+	profiles, ok := api.equipmentProfiles(w, ctx, userID)
+	if !ok {
+		return models.EquipmentProfile{}, false
+	}
+
+	id, idErr := primitive.ObjectIDFromHex(ref)
+	for _, profile := range profiles {
+		if (idErr == nil && profile.ID == id) || profile.Slug == slug.Make(ref) {
+			return profile, true
+		}
+	}
+	writeError(w, http.StatusNotFound, fmt.Sprintf("equipment profile %q not found", ref))
+	return models.EquipmentProfile{}, false
+}
+
+// gymEquipment returns the equipment of the profile named by the gym
+// parameter, or nil if the request has none. It writes an error response
+// and returns false if the profile cannot be used.
+func (api *API) gymEquipment(w http.ResponseWriter, r *http.Request) ([]string, bool) {
+	q := r.URL.Query()
+	ref := strings.TrimSpace(q.Get("gym"))
+	if ref == "" {
+		return nil, true
+	}
+	if q.Has("equipment") {
+		writeError(w, http.StatusBadRequest, "gym and equipment parameters cannot be combined")
+		return nil, false
+	}
+	identity, ok := auth.IdentityFromContext(r.Context())
+	if !ok {
+		unauthorized(w, "the gym parameter requires authentication")
+		return nil, false
+	}
+
+	profile, ok := api.lookupGym(w, r.Context(), identity.UserID, ref)
+	if !ok {
+		return nil, false
+	}
+	return profile.Equipment, true
+}
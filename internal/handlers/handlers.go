@@ -2,120 +2,149 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"net/http"
-	"strings"
 
+	"fitness-framework-api/internal/auth"
+	"fitness-framework-api/internal/constants"
+	"fitness-framework-api/internal/media"
+	"fitness-framework-api/internal/migrations"
 	"fitness-framework-api/internal/models"
-	"fitness-framework-api/internal/mongodb"
-
-	"go.mongodb.org/mongo-driver/mongo"
+	"fitness-framework-api/internal/store"
 )
 
 type API struct {
-	DB          *mongo.Database
-	VersionInfo *models.ApiInfo
+	Store         store.ExerciseStore
+	Users         store.UserStore
+	Audit         store.AuditStore
+	Profiles      store.ProfileStore
+	Workouts      store.WorkoutStore
+	Auth          *auth.Issuer
+	VersionInfo   *models.ApiInfo
+	Migrator      *migrations.Migrator
+	OptionsFormat string
+	Blobs         media.BlobStore
+	MediaBaseURL  string
 }
 
-func NewAPI(db *mongo.Database, versionInfo *models.ApiInfo) *API {
-	return &API{DB: db, VersionInfo: versionInfo}
+func NewAPI(s store.ExerciseStore, versionInfo *models.ApiInfo) *API {
+	return &API{Store: s, VersionInfo: versionInfo}
 }
 
-func (api *API) GetExercisesHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+func (api *API) ExercisesHandler(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w, "GET, POST, OPTIONS")
 
-	if r.Method == "OPTIONS" {
+	switch r.Method {
+	case http.MethodOptions:
 		w.WriteHeader(http.StatusOK)
-		return
+	case http.MethodGet:
+		api.listExercises(w, r)
+	case http.MethodPost:
+		api.createExercise(w, r, nil)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 	}
+}
 
-	equipmentFilters := r.URL.Query()["equipment"]
-	musclesFilters := r.URL.Query()["muscles"]
-
-	allExercises, err := mongodb.GetExercises(api.DB)
+func (api *API) listExercises(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseExerciseFilter(r.URL.Query())
 	if err != nil {
-		slog.Error("Error getting all exercises from MongoDB", "error", err)
-		http.Error(w, "Failed to fetch exercises: "+err.Error(), http.StatusInternalServerError)
+		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	var filteredExercises []models.Exercise
+	page, err := parsePageRequest(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
-	for _, ex := range allExercises {
-		equipmentMatches := containsAnyCaseInsensitive(ex.Equipment, equipmentFilters)
-		musclesMatches := containsAnyCaseInsensitive(ex.Muscles, musclesFilters)
+	view, err := parseView(r.URL.Query(), viewSummary)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
-		if equipmentMatches && musclesMatches {
-			filteredExercises = append(filteredExercises, ex)
-		}
+	localizer, ok := api.localizer(w, r)
+	if !ok {
+		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(filteredExercises)
-}
+	if identity, ok := auth.IdentityFromContext(r.Context()); ok {
+		filter.Owner = &identity.UserID
+	}
+	switch owner := r.URL.Query().Get("owner"); {
+	case owner == "":
+	case owner != "all":
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("unsupported owner %q", owner))
+		return
+	case !hasRole(r, models.RoleAdmin):
+		writeError(w, http.StatusForbidden, "only admins can list the custom exercises of all users")
+		return
+	default:
+		filter.AllOwners = true
+	}
 
-func containsAnyCaseInsensitive(haystack []string, needles []string) bool {
-	if len(needles) == 0 {
-		return true
+	gym, ok := api.gymEquipment(w, r)
+	if !ok {
+		return
 	}
-	for _, needle := range needles {
-		for _, item := range haystack {
-			if strings.EqualFold(item, needle) {
-				return true
-			}
+	if gym != nil {
+		if !r.URL.Query().Has("equipment_match") {
+			filter.Equipment.Mode = store.MatchSubset
+		}
+		filter.Equipment.Values = gym
+		if filter.Equipment.Mode == store.MatchSubset {
+			filter.Equipment.Values = append(filter.Equipment.Values, constants.EquipmentNone)
 		}
 	}
-	return false
-}
-
-func (api *API) GetEquipmentOptionsHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
 
-	if r.Method == "OPTIONS" {
-		w.WriteHeader(http.StatusOK)
-		return
+	if len(filter.Muscles.Values) > 0 || len(filter.Muscles.Exclude) > 0 {
+		muscles, err := api.Store.GetOptions(r.Context(), store.OptionMuscle)
+		if err != nil {
+			api.writeStoreError(w, "get exercises", err)
+			return
+		}
+		filter.Muscles.Expansions = store.Descendants(muscles)
 	}
 
-	equipment, err := mongodb.GetUniqueEquipment(api.DB)
+	result, err := api.Store.ListExercises(r.Context(), filter, page)
 	if err != nil {
-		slog.Error("Error getting unique equipment from MongoDB", "error", err)
-		http.Error(w, "Failed to fetch equipment options: "+err.Error(), http.StatusInternalServerError)
+		api.writeStoreError(w, "get exercises", err)
 		return
 	}
+	// Cursors hold the sort values of the stored exercises, so they are
+	// taken before the page is translated.
+	resp := newExerciseListResponse(r, page, result)
+	for i := range resp.Data {
+		localizer.Exercise(&resp.Data[i])
+		applyView(&resp.Data[i], view)
+		api.setMediaURLs(&resp.Data[i])
+	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(equipment)
+	json.NewEncoder(w).Encode(resp)
 }
 
-func (api *API) GetMusclesOptionsHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+func (api *API) GetAvailableExercisesHandler(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w, "GET, OPTIONS")
 
 	if r.Method == "OPTIONS" {
 		w.WriteHeader(http.StatusOK)
 		return
 	}
 
-	muscles, err := mongodb.GetUniqueMuscles(api.DB)
-	if err != nil {
-		slog.Error("Error getting unique muscles from MongoDB", "error", err)
-		http.Error(w, "Failed to fetch muscle options: "+err.Error(), http.StatusInternalServerError)
-		return
-	}
+	r = r.Clone(r.Context())
+	q := r.URL.Query()
+	q.Set("equipment_match", string(store.MatchSubset))
+	r.URL.RawQuery = q.Encode()
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(muscles)
+	api.listExercises(w, r)
 }
 
 func (api *API) GetVersionHandler(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+	setCORSHeaders(w, "GET, OPTIONS")
 
 	if r.Method == "OPTIONS" {
 		w.WriteHeader(http.StatusOK)
@@ -128,6 +157,16 @@ func (api *API) GetVersionHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	info := *api.VersionInfo
+	if api.Migrator != nil {
+		status, err := api.Migrator.Status(r.Context())
+		if err != nil {
+			slog.Error("Error getting migration status", "error", err)
+		} else {
+			info.Migrations = status
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(api.VersionInfo)
+	json.NewEncoder(w).Encode(info)
 }
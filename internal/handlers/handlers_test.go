@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fitness-framework-api/internal/auth"
+	"fitness-framework-api/internal/models"
+	"fitness-framework-api/internal/store"
+)
+
+func TestExercisesWithMemoryStore(t *testing.T) {
+	api, s := newTestAPI(t)
+	ctx := context.Background()
+	for _, name := range []string{"Barbell", "Dumbbells"} {
+		if err := s.CreateOption(ctx, store.OptionEquipment, &models.Option{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.CreateOption(ctx, store.OptionMuscle, &models.Option{Name: "Chest"}); err != nil {
+		t.Fatal(err)
+	}
+	admin := auth.Identity{UserID: primitive.NewObjectID(), SessionID: primitive.NewObjectID(), Role: models.RoleAdmin}
+	list := api.RequireRoleToWrite(models.RoleAdmin, api.ExercisesHandler)
+	item := api.RequireRoleToWrite(models.RoleUser, api.ExerciseHandler)
+
+	for _, in := range []exerciseInput{
+		{Name: "Barbell Bench Press", Equipment: []string{"barbell"}, Muscles: []string{"Chest"}},
+		{Name: "Dumbbell Bench Press", Equipment: []string{"Dumbbells"}, Muscles: []string{"chest"}},
+	} {
+		r := withToken(api, newRequest(t, http.MethodPost, "/api/exercises", in), admin)
+		if rec := serve("/api/exercises", list, r); rec.Code != http.StatusCreated {
+			t.Fatalf("create %s: status %d: %s", in.Name, rec.Code, rec.Body)
+		}
+	}
+	r := withToken(api, newRequest(t, http.MethodPost, "/api/exercises", exerciseInput{Name: "barbell bench press", Equipment: []string{"Barbell"}, Muscles: []string{"Chest"}}), admin)
+	if rec := serve("/api/exercises", list, r); rec.Code != http.StatusBadRequest {
+		t.Errorf("create duplicate: status %d, want %d: %s", rec.Code, http.StatusBadRequest, rec.Body)
+	}
+
+	rec := serve("/api/exercises", list, newRequest(t, http.MethodGet, "/api/exercises?equipment=BARBELL", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("list: status %d: %s", rec.Code, rec.Body)
+	}
+	page := decodeBody[exerciseListResponse](t, rec)
+	if page.Total != 1 || len(page.Data) != 1 || page.Data[0].Name != "Barbell Bench Press" {
+		t.Fatalf("list by equipment returned %+v", page)
+	}
+
+	rec = serve("/api/exercises/{id}", item, newRequest(t, http.MethodGet, "/api/exercises/barbell-bench-press", nil))
+	if rec.Code != http.StatusOK || decodeBody[models.Exercise](t, rec).ID != page.Data[0].ID {
+		t.Fatalf("get by slug: status %d: %s", rec.Code, rec.Body)
+	}
+
+	r = withToken(api, newRequest(t, http.MethodDelete, "/api/exercises/"+page.Data[0].ID.Hex(), nil), admin)
+	if rec := serve("/api/exercises/{id}", item, r); rec.Code != http.StatusNoContent {
+		t.Fatalf("delete: status %d: %s", rec.Code, rec.Body)
+	}
+	rec = serve("/api/exercises/{id}", item, newRequest(t, http.MethodGet, "/api/exercises/"+page.Data[0].ID.Hex(), nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("get after delete: status %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
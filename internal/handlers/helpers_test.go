@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"fitness-framework-api/internal/auth"
+	"fitness-framework-api/internal/memory"
+	"fitness-framework-api/internal/models"
+)
+
+// newTestAPI returns an API backed by an empty memory store.
+func newTestAPI(t *testing.T) (*API, *memory.Store) {
+	t.Helper()
+	s := memory.NewStore()
+	api := NewAPI(s, &models.ApiInfo{})
+	api.Users = s
+	api.Audit = s
+	api.Profiles = s
+	api.Workouts = s
+	api.Auth = auth.NewIssuer([]byte("test secret"))
+	return api, s
+}
+
+// newRequest returns a request with body encoded as JSON, unless it is nil.
+func newRequest(t *testing.T, method, target string, body any) *http.Request {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r := httptest.NewRequest(method, target, &buf)
+	r.Header.Set("Content-Type", "application/json")
+	return r
+}
+
+// as makes identity the caller of r, as the auth middleware does.
+func as(r *http.Request, identity auth.Identity) *http.Request {
+	return r.WithContext(auth.WithIdentity(r.Context(), identity))
+}
+
+// withToken authenticates r with an access token for identity.
+func withToken(api *API, r *http.Request, identity auth.Identity) *http.Request {
+	token, _ := api.Auth.AccessToken(identity, time.Now())
+	r.Header.Set("Authorization", "Bearer "+token)
+	return r
+}
+
+// serve routes r to handler registered under pattern, so that path values
+// are set.
+func serve(pattern string, handler http.HandlerFunc, r *http.Request) *httptest.ResponseRecorder {
+	mux := http.NewServeMux()
+	mux.HandleFunc(pattern, handler)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, r)
+	return rec
+}
+
+func decodeBody[T any](t *testing.T, rec *httptest.ResponseRecorder) T {
+	t.Helper()
+	var v T
+	if err := json.Unmarshal(rec.Body.Bytes(), &v); err != nil {
+		t.Fatalf("invalid response body %q: %v", rec.Body.String(), err)
+	}
+	return v
+}
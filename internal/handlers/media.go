@@ -0,0 +1,290 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fitness-framework-api/internal/media"
+	"fitness-framework-api/internal/models"
+)
+
+const DefaultMediaBaseURL = "/api/media"
+
+func (api *API) ExerciseMediaHandler(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w, "GET, POST, OPTIONS")
+
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		exercise, ok := api.lookupExercise(w, r)
+		if !ok {
+			return
+		}
+		api.setMediaURLs(exercise)
+		if exercise.Media == nil {
+			exercise.Media = []models.Media{}
+		}
+		writeJSON(w, http.StatusOK, exercise.Media)
+	case http.MethodPost:
+		api.uploadMedia(w, r)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (api *API) ExerciseMediaItemHandler(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w, "GET, DELETE, OPTIONS")
+
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		exercise, item, ok := api.lookupMedia(w, r)
+		if !ok {
+			return
+		}
+		api.setMediaURLs(exercise)
+		writeJSON(w, http.StatusOK, exercise.Media[item])
+	case http.MethodDelete:
+		api.deleteMedia(w, r)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (api *API) MediaFileHandler(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w, "GET, OPTIONS")
+
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusOK)
+		return
+	case http.MethodGet, http.MethodHead:
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	key := r.PathValue("key")
+	f, err := api.Blobs.Open(r.Context(), key)
+	if errors.Is(err, fs.ErrNotExist) || errors.Is(err, media.ErrInvalidKey) {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	if err != nil {
+		slog.Error("Error opening media file", "key", key, "error", err)
+		writeError(w, http.StatusInternalServerError, "failed to open media file")
+		return
+	}
+	defer f.Close()
+
+	if contentType := mime.TypeByExtension(path.Ext(key)); contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	http.ServeContent(w, r, path.Base(key), time.Time{}, f)
+}
+
+func (api *API) uploadMedia(w http.ResponseWriter, r *http.Request) {
+	exercise, ok := api.lookupExercise(w, r)
+	if !ok || !canModify(w, r, *exercise) {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, media.MaxVideoBytes+media.MaxImageBytes+1<<20)
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, "upload is too large")
+			return
+		}
+		writeError(w, http.StatusBadRequest, "invalid multipart form: "+err.Error())
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	data, err := readFormFile(r, "file")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "validation failed", fieldError{Field: "file", Message: err.Error()})
+		return
+	}
+	if data == nil {
+		writeError(w, http.StatusBadRequest, "validation failed", fieldError{Field: "file", Message: "file is required"})
+		return
+	}
+
+	info, err := media.Inspect(data)
+	if err != nil {
+		writeError(w, http.StatusUnsupportedMediaType, err.Error())
+		return
+	}
+	if limit := media.MaxBytes(info.Kind); int64(len(data)) > limit {
+		writeError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("%s uploads are limited to %d MB", info.Kind, limit>>20))
+		return
+	}
+	if info.Pixels() > media.MaxImagePixels {
+		writeError(w, http.StatusRequestEntityTooLarge, media.ErrTooManyPixels.Error())
+		return
+	}
+
+	item := models.Media{
+		ID:          primitive.NewObjectID(),
+		Kind:        info.Kind,
+		ContentType: info.ContentType,
+		Size:        int64(len(data)),
+		Width:       info.Width,
+		Height:      info.Height,
+		Caption:     strings.TrimSpace(r.FormValue("caption")),
+		CreatedAt:   time.Now().UTC(),
+	}
+	prefix := fmt.Sprintf("exercises/%s/%s", exercise.ID.Hex(), item.ID.Hex())
+	item.Key = prefix + info.Ext
+
+	thumbnail, err := readFormFile(r, "thumbnail")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "validation failed", fieldError{Field: "thumbnail", Message: err.Error()})
+		return
+	}
+	if thumbnail != nil {
+		thumbInfo, err := media.Inspect(thumbnail)
+		if err != nil || thumbInfo.Kind != media.KindImage || int64(len(thumbnail)) > media.MaxImageBytes || thumbInfo.Pixels() > media.MaxImagePixels {
+			writeError(w, http.StatusBadRequest, "validation failed", fieldError{Field: "thumbnail", Message: "thumbnail must be a JPEG, PNG or WebP image of at most 10 MB"})
+			return
+		}
+		item.Thumbnail = &models.Thumbnail{ContentType: thumbInfo.ContentType, Width: thumbInfo.Width, Height: thumbInfo.Height, Key: prefix + "-thumb" + thumbInfo.Ext}
+	} else if info.Kind != media.KindVideo && info.Width > 0 {
+		var width, height int
+		thumbnail, width, height, err = media.Thumbnail(data)
+		if err != nil {
+			slog.Warn("Could not generate media thumbnail", "exercise", exercise.Name, "error", err)
+			thumbnail = nil
+		} else {
+			item.Thumbnail = &models.Thumbnail{ContentType: "image/jpeg", Width: width, Height: height, Key: prefix + "-thumb.jpg"}
+		}
+	}
+
+	if _, err := api.Blobs.Put(r.Context(), item.Key, bytes.NewReader(data)); err != nil {
+		slog.Error("Error storing media file", "key", item.Key, "error", err)
+		writeError(w, http.StatusInternalServerError, "failed to store media file")
+		return
+	}
+	if item.Thumbnail != nil {
+		if _, err := api.Blobs.Put(r.Context(), item.Thumbnail.Key, bytes.NewReader(thumbnail)); err != nil {
+			slog.Error("Error storing media thumbnail", "key", item.Thumbnail.Key, "error", err)
+			api.deleteMediaFiles(r.Context(), []models.Media{{Key: item.Key}})
+			writeError(w, http.StatusInternalServerError, "failed to store media file")
+			return
+		}
+	}
+
+	if err := api.Store.AddExerciseMedia(r.Context(), exercise.ID, item); err != nil {
+		api.deleteMediaFiles(r.Context(), []models.Media{item})
+		api.writeStoreError(w, "add media", err)
+		return
+	}
+	api.auditExercise(r, "exercise.media.add", *exercise)
+
+	api.setMediaItemURLs(&item)
+	w.Header().Set("Location", fmt.Sprintf("/api/exercises/%s/media/%s", exercise.ID.Hex(), item.ID.Hex()))
+	writeJSON(w, http.StatusCreated, item)
+}
+
+func (api *API) deleteMedia(w http.ResponseWriter, r *http.Request) {
+	exercise, item, ok := api.lookupMedia(w, r)
+	if !ok || !canModify(w, r, *exercise) {
+		return
+	}
+
+	if err := api.Store.RemoveExerciseMedia(r.Context(), exercise.ID, exercise.Media[item].ID); err != nil {
+		api.writeStoreError(w, "remove media", err)
+		return
+	}
+	api.deleteMediaFiles(r.Context(), exercise.Media[item:item+1])
+	api.auditExercise(r, "exercise.media.remove", *exercise)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (api *API) lookupMedia(w http.ResponseWriter, r *http.Request) (*models.Exercise, int, bool) {
+	exercise, ok := api.lookupExercise(w, r)
+	if !ok {
+		return nil, 0, false
+	}
+
+	id, err := primitive.ObjectIDFromHex(r.PathValue("mediaId"))
+	i := slices.IndexFunc(exercise.Media, func(m models.Media) bool { return m.ID == id })
+	if err != nil || i < 0 {
+		writeError(w, http.StatusNotFound, "not found")
+		return nil, 0, false
+	}
+	return exercise, i, true
+}
+
+func (api *API) deleteMediaFiles(ctx context.Context, items []models.Media) {
+	for _, item := range items {
+		keys := []string{item.Key}
+		if item.Thumbnail != nil {
+			keys = append(keys, item.Thumbnail.Key)
+		}
+		for _, key := range keys {
+			if err := api.Blobs.Delete(ctx, key); err != nil {
+				slog.Warn("Could not delete media file", "key", key, "error", err)
+			}
+		}
+	}
+}
+
+func (api *API) setMediaURLs(exercise *models.Exercise) {
+	for i := range exercise.Media {
+		api.setMediaItemURLs(&exercise.Media[i])
+	}
+}
+
+func (api *API) setMediaItemURLs(item *models.Media) {
+	item.URL = api.mediaURL(item.Key)
+	if item.Thumbnail != nil {
+		item.Thumbnail.URL = api.mediaURL(item.Thumbnail.Key)
+	}
+}
+
+func (api *API) mediaURL(key string) string {
+	base := api.MediaBaseURL
+	if base == "" {
+		base = DefaultMediaBaseURL
+	}
+	return strings.TrimSuffix(base, "/") + "/" + key
+}
+
+func readFormFile(r *http.Request, field string) ([]byte, error) {
+	file, _, err := r.FormFile(field)
+	if errors.Is(err, http.ErrMissingFile) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("file is empty")
+	}
+	return data, nil
+}
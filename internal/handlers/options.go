@@ -0,0 +1,486 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fitness-framework-api/internal/models"
+	"fitness-framework-api/internal/store"
+)
+
+const (
+	OptionsFormatObjects = "objects"
+	OptionsFormatNames   = "names"
+	OptionsFormatTree    = "tree"
+)
+
+type optionInput struct {
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Category    string              `json:"category"`
+	ParentID    *primitive.ObjectID `json:"parentId"`
+}
+
+func inputFromOption(option models.Option) optionInput {
+	return optionInput{
+		Name:        option.Name,
+		Description: option.Description,
+		Category:    option.Category,
+		ParentID:    option.ParentID,
+	}
+}
+
+func (in optionInput) apply(option *models.Option) {
+	option.Name = strings.TrimSpace(in.Name)
+	option.Description = strings.TrimSpace(in.Description)
+	option.Category = strings.TrimSpace(in.Category)
+	option.ParentID = in.ParentID
+}
+
+type optionNode struct {
+	models.Option
+	Children []optionNode `json:"children"`
+}
+
+func optionTree(opts []models.Option) []optionNode {
+	children := make(map[primitive.ObjectID][]models.Option)
+	var roots []models.Option
+	for _, option := range opts {
+		if option.ParentID != nil && slices.ContainsFunc(opts, func(o models.Option) bool { return o.ID == *option.ParentID }) {
+			children[*option.ParentID] = append(children[*option.ParentID], option)
+		} else {
+			roots = append(roots, option)
+		}
+	}
+
+	var build func(level []models.Option, seen map[primitive.ObjectID]bool) []optionNode
+	build = func(level []models.Option, seen map[primitive.ObjectID]bool) []optionNode {
+		nodes := make([]optionNode, 0, len(level))
+		for _, option := range level {
+			if seen[option.ID] {
+				continue
+			}
+			seen[option.ID] = true
+			nodes = append(nodes, optionNode{Option: option, Children: build(children[option.ID], seen)})
+		}
+		return nodes
+	}
+	return build(roots, make(map[primitive.ObjectID]bool))
+}
+
+type mergeInput struct {
+	Into string `json:"into"`
+}
+
+type optionChangeResponse struct {
+	Option           models.Option `json:"option"`
+	ExercisesUpdated int64         `json:"exercisesUpdated"`
+}
+
+func (api *API) EquipmentOptionsHandler(w http.ResponseWriter, r *http.Request) {
+	api.optionsHandler(w, r, store.OptionEquipment)
+}
+
+func (api *API) MusclesOptionsHandler(w http.ResponseWriter, r *http.Request) {
+	api.optionsHandler(w, r, store.OptionMuscle)
+}
+
+func (api *API) EquipmentOptionHandler(w http.ResponseWriter, r *http.Request) {
+	api.optionHandler(w, r, store.OptionEquipment)
+}
+
+func (api *API) MusclesOptionHandler(w http.ResponseWriter, r *http.Request) {
+	api.optionHandler(w, r, store.OptionMuscle)
+}
+
+func (api *API) MergeEquipmentOptionHandler(w http.ResponseWriter, r *http.Request) {
+	api.mergeOptionHandler(w, r, store.OptionEquipment)
+}
+
+func (api *API) MergeMusclesOptionHandler(w http.ResponseWriter, r *http.Request) {
+	api.mergeOptionHandler(w, r, store.OptionMuscle)
+}
+
+func (api *API) optionsHandler(w http.ResponseWriter, r *http.Request, kind store.OptionKind) {
+	setCORSHeaders(w, "GET, POST, OPTIONS")
+
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		api.listOptions(w, r, kind)
+	case http.MethodPost:
+		api.createOption(w, r, kind)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (api *API) optionHandler(w http.ResponseWriter, r *http.Request, kind store.OptionKind) {
+	setCORSHeaders(w, "GET, PUT, PATCH, DELETE, OPTIONS")
+
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		localizer, ok := api.localizer(w, r)
+		if !ok {
+			return
+		}
+		if option, ok := api.lookupOption(w, r.Context(), kind, r.PathValue("option")); ok {
+			localizer.Option(&option)
+			writeJSON(w, http.StatusOK, option)
+		}
+	case http.MethodPut:
+		api.updateOption(w, r, kind, false)
+	case http.MethodPatch:
+		api.updateOption(w, r, kind, true)
+	case http.MethodDelete:
+		api.deleteOption(w, r, kind)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (api *API) listOptions(w http.ResponseWriter, r *http.Request, kind store.OptionKind) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = api.OptionsFormat
+	}
+	if format == "" {
+		format = OptionsFormatNames
+	}
+	if format != OptionsFormatObjects && format != OptionsFormatNames && format != OptionsFormatTree {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("unsupported format %q", format))
+		return
+	}
+
+	opts, err := api.Store.GetOptions(r.Context(), kind)
+	if err != nil {
+		slog.Error("Error getting options from store", "kind", kind, "error", err)
+		http.Error(w, fmt.Sprintf("Failed to fetch %s options: %s", kind, err.Error()), http.StatusInternalServerError)
+		return
+	}
+
+	localizer, ok := api.localizer(w, r)
+	if !ok {
+		return
+	}
+	for i := range opts {
+		localizer.Option(&opts[i])
+	}
+
+	if format == OptionsFormatNames {
+		names := make([]string, 0, len(opts))
+		for _, option := range opts {
+			names = append(names, option.Name)
+		}
+		slices.Sort(names)
+		writeJSON(w, http.StatusOK, names)
+		return
+	}
+
+	if format == OptionsFormatTree {
+		writeJSON(w, http.StatusOK, optionTree(opts))
+		return
+	}
+
+	if opts == nil {
+		opts = []models.Option{}
+	}
+	writeJSON(w, http.StatusOK, opts)
+}
+
+func (api *API) createOption(w http.ResponseWriter, r *http.Request, kind store.OptionKind) {
+	var in optionInput
+	if err := decodeJSON(r, &in); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	var option models.Option
+	in.apply(&option)
+	if !api.validateOption(w, r.Context(), kind, option) {
+		return
+	}
+
+	if err := api.Store.CreateOption(r.Context(), kind, &option); err != nil {
+		api.writeStoreError(w, "create option", err)
+		return
+	}
+	api.audit(r, string(kind)+".create", string(kind), option.ID.Hex(), option.Name)
+
+	writeJSON(w, http.StatusCreated, option)
+}
+
+func (api *API) updateOption(w http.ResponseWriter, r *http.Request, kind store.OptionKind, partial bool) {
+	current, ok := api.lookupOption(w, r.Context(), kind, r.PathValue("option"))
+	if !ok {
+		return
+	}
+
+	var in optionInput
+	if partial {
+		in = inputFromOption(current)
+	}
+	if err := decodeJSON(r, &in); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	option := current
+	in.apply(&option)
+	if !api.validateOption(w, r.Context(), kind, option) {
+		return
+	}
+
+	if err := api.Store.UpdateOption(r.Context(), kind, option); err != nil {
+		api.writeStoreError(w, "update option", err)
+		return
+	}
+
+	var updated int64
+	if option.Name != current.Name {
+		var err error
+		updated, err = api.Store.ReplaceOptionInExercises(r.Context(), kind, current, &option)
+		if err != nil {
+			api.writeStoreError(w, "update exercises", err)
+			return
+		}
+	}
+	api.audit(r, string(kind)+".update", string(kind), option.ID.Hex(), option.Name)
+
+	writeJSON(w, http.StatusOK, optionChangeResponse{Option: option, ExercisesUpdated: updated})
+}
+
+func (api *API) deleteOption(w http.ResponseWriter, r *http.Request, kind store.OptionKind) {
+	current, ok := api.lookupOption(w, r.Context(), kind, r.PathValue("option"))
+	if !ok {
+		return
+	}
+
+	usage, err := api.Store.CountOptionUsage(r.Context(), kind, current.ID)
+	if err != nil {
+		api.writeStoreError(w, "count option usage", err)
+		return
+	}
+
+	children, err := api.childOptions(r.Context(), kind, current.ID)
+	if err != nil {
+		api.writeStoreError(w, "get options", err)
+		return
+	}
+
+	force := r.URL.Query().Get("force") == "true"
+	if usage > 0 && !force {
+		writeError(w, http.StatusConflict, fmt.Sprintf("%s option %q is used by %d exercise(s); pass force=true to remove it from them", kind, current.Name, usage))
+		return
+	}
+	if len(children) > 0 && !force {
+		writeError(w, http.StatusConflict, fmt.Sprintf("%s option %q has %d child option(s); pass force=true to move them to its parent", kind, current.Name, len(children)))
+		return
+	}
+	if usage > 0 {
+		sole, err := api.soleOptionUsers(r.Context(), kind, current)
+		if err != nil {
+			api.writeStoreError(w, "get exercises", err)
+			return
+		}
+		if len(sole) > 0 {
+			writeError(w, http.StatusConflict, fmt.Sprintf("%s option %q is the only %s of %d exercise(s), such as %q; merge it into another option instead", kind, current.Name, kind, len(sole), sole[0].Name))
+			return
+		}
+	}
+	if err := api.reparentOptions(r.Context(), kind, children, current.ParentID); err != nil {
+		api.writeStoreError(w, "update options", err)
+		return
+	}
+
+	if usage > 0 {
+		if _, err := api.Store.ReplaceOptionInExercises(r.Context(), kind, current, nil); err != nil {
+			api.writeStoreError(w, "update exercises", err)
+			return
+		}
+	}
+
+	if err := store.DeleteOption(r.Context(), api.Store, api.Profiles, kind, current.ID); err != nil {
+		api.writeStoreError(w, "delete option", err)
+		return
+	}
+	api.audit(r, string(kind)+".delete", string(kind), current.ID.Hex(), current.Name)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// soleOptionUsers returns the exercises that have no other option of the
+// kind, which removing the option would leave without any.
+func (api *API) soleOptionUsers(ctx context.Context, kind store.OptionKind, option models.Option) ([]models.Exercise, error) {
+	only := store.SetFilter{Values: []string{option.Name}, Mode: store.MatchExact}
+	filter := store.ExerciseFilter{AllOwners: true}
+	if kind == store.OptionEquipment {
+		filter.Equipment = only
+	} else {
+		filter.Muscles = only
+	}
+	return api.Store.GetExercises(ctx, filter)
+}
+
+func (api *API) mergeOptionHandler(w http.ResponseWriter, r *http.Request, kind store.OptionKind) {
+	setCORSHeaders(w, "POST, OPTIONS")
+
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusOK)
+		return
+	case http.MethodPost:
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	source, ok := api.lookupOption(w, r.Context(), kind, r.PathValue("option"))
+	if !ok {
+		return
+	}
+
+	var in mergeInput
+	if err := decodeJSON(r, &in); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	target, ok := api.lookupOption(w, r.Context(), kind, in.Into)
+	if !ok {
+		return
+	}
+	if target.ID == source.ID {
+		writeError(w, http.StatusBadRequest, "validation failed", fieldError{Field: "into", Message: "cannot merge an option into itself"})
+		return
+	}
+
+	updated, err := api.Store.ReplaceOptionInExercises(r.Context(), kind, source, &target)
+	if err != nil {
+		api.writeStoreError(w, "update exercises", err)
+		return
+	}
+
+	children, err := api.childOptions(r.Context(), kind, source.ID)
+	if err != nil {
+		api.writeStoreError(w, "get options", err)
+		return
+	}
+	for i := range children {
+		if children[i].ID == target.ID {
+			target.ParentID = source.ParentID
+			if err := api.Store.UpdateOption(r.Context(), kind, target); err != nil {
+				api.writeStoreError(w, "update option", err)
+				return
+			}
+			children = slices.Delete(children, i, i+1)
+			break
+		}
+	}
+	if err := api.reparentOptions(r.Context(), kind, children, &target.ID); err != nil {
+		api.writeStoreError(w, "update options", err)
+		return
+	}
+
+	if kind == store.OptionEquipment {
+		if err := api.Profiles.MergeProfileEquipment(r.Context(), source.ID, target.ID); err != nil {
+			api.writeStoreError(w, "update equipment profiles", err)
+			return
+		}
+	}
+
+	if err := api.Store.DeleteOption(r.Context(), kind, source.ID); err != nil {
+		api.writeStoreError(w, "delete option", err)
+		return
+	}
+	api.audit(r, string(kind)+".merge", string(kind), source.ID.Hex(), source.Name+" into "+target.Name)
+
+	writeJSON(w, http.StatusOK, optionChangeResponse{Option: target, ExercisesUpdated: updated})
+}
+
+func (api *API) lookupOption(w http.ResponseWriter, ctx context.Context, kind store.OptionKind, ref string) (models.Option, bool) {
+	opts, err := api.Store.GetOptions(ctx, kind)
+	if err != nil {
+		api.writeStoreError(w, "get options", err)
+		return models.Option{}, false
+	}
+
+	ref = strings.TrimSpace(ref)
+	id, idErr := primitive.ObjectIDFromHex(ref)
+	for _, option := range opts {
+		if (idErr == nil && option.ID == id) || strings.EqualFold(option.Name, ref) {
+			return option, true
+		}
+	}
+
+	writeError(w, http.StatusNotFound, fmt.Sprintf("%s option %q not found", kind, ref))
+	return models.Option{}, false
+}
+
+func (api *API) validateOption(w http.ResponseWriter, ctx context.Context, kind store.OptionKind, option models.Option) bool {
+	if option.Name == "" {
+		writeError(w, http.StatusBadRequest, "validation failed", fieldError{Field: "name", Message: "name is required"})
+		return false
+	}
+
+	opts, err := api.Store.GetOptions(ctx, kind)
+	if err != nil {
+		api.writeStoreError(w, "get options", err)
+		return false
+	}
+
+	for _, other := range opts {
+		if strings.EqualFold(other.Name, option.Name) && other.ID != option.ID {
+			writeError(w, http.StatusBadRequest, "validation failed", fieldError{Field: "name", Message: fmt.Sprintf("%s option %q already exists", kind, other.Name)})
+			return false
+		}
+	}
+
+	for parentID := option.ParentID; parentID != nil; {
+		if *parentID == option.ID {
+			writeError(w, http.StatusBadRequest, "validation failed", fieldError{Field: "parentId", Message: "an option cannot be its own ancestor"})
+			return false
+		}
+		i := slices.IndexFunc(opts, func(o models.Option) bool { return o.ID == *parentID })
+		if i < 0 {
+			writeError(w, http.StatusBadRequest, "validation failed", fieldError{Field: "parentId", Message: fmt.Sprintf("%q is not a registered %s option id", parentID.Hex(), kind)})
+			return false
+		}
+		parentID = opts[i].ParentID
+	}
+	return true
+}
+
+func (api *API) childOptions(ctx context.Context, kind store.OptionKind, id primitive.ObjectID) ([]models.Option, error) {
+	opts, err := api.Store.GetOptions(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	var children []models.Option
+	for _, option := range opts {
+		if option.ParentID != nil && *option.ParentID == id {
+			children = append(children, option)
+		}
+	}
+	return children, nil
+}
+
+func (api *API) reparentOptions(ctx context.Context, kind store.OptionKind, children []models.Option, parentID *primitive.ObjectID) error {
+	for _, child := range children {
+		child.ParentID = parentID
+		if err := api.Store.UpdateOption(ctx, kind, child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fitness-framework-api/internal/auth"
+	"fitness-framework-api/internal/models"
+	"fitness-framework-api/internal/store"
+)
+
+func TestListOptionsFormat(t *testing.T) {
+	api, s := newTestAPI(t)
+	for _, name := range []string{"Dumbbells", "Barbell"} {
+		if err := s.CreateOption(context.Background(), store.OptionEquipment, &models.Option{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		setting string
+		query   string
+		prefix  string
+	}{
+		{"default", "", "", `["Barbell","Dumbbells"]`},
+		{"names", "", "?format=names", `["Barbell","Dumbbells"]`},
+		{"objects", "", "?format=objects", `[{"id":`},
+		{"tree", "", "?format=tree", `[{"id":`},
+		{"objects by default", OptionsFormatObjects, "", `[{"id":`},
+		{"names with objects by default", OptionsFormatObjects, "?format=names", `["Barbell","Dumbbells"]`},
+	}
+	for _, tt := range tests {
+		api.OptionsFormat = tt.setting
+		rec := serve("/api/equipment-options", api.EquipmentOptionsHandler, newRequest(t, http.MethodGet, "/api/equipment-options"+tt.query, nil))
+		if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Body.String(), tt.prefix) {
+			t.Errorf("%s: status %d with body %s, want %s...", tt.name, rec.Code, rec.Body, tt.prefix)
+		}
+	}
+}
+
+func TestDeleteEquipmentOptionUpdatesProfiles(t *testing.T) {
+	api, s := newTestAPI(t)
+	ctx := context.Background()
+	barbell, dumbbells := models.Option{Name: "Barbell"}, models.Option{Name: "Dumbbells"}
+	for _, option := range []*models.Option{&barbell, &dumbbells} {
+		if err := s.CreateOption(ctx, store.OptionEquipment, option); err != nil {
+			t.Fatal(err)
+		}
+	}
+	user := primitive.NewObjectID()
+	profile := &models.EquipmentProfile{UserID: user, Name: "Home", EquipmentIDs: []primitive.ObjectID{barbell.ID, dumbbells.ID}}
+	if err := s.CreateEquipmentProfile(ctx, profile); err != nil {
+		t.Fatal(err)
+	}
+
+	r := as(newRequest(t, http.MethodDelete, "/api/equipment-options/Barbell", nil), auth.Identity{UserID: primitive.NewObjectID(), Role: models.RoleAdmin})
+	if rec := serve("/api/equipment-options/{option}", api.EquipmentOptionHandler, r); rec.Code != http.StatusNoContent {
+		t.Fatalf("delete: status %d: %s", rec.Code, rec.Body)
+	}
+	profiles, err := s.GetEquipmentProfiles(ctx, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(profiles) != 1 || len(profiles[0].EquipmentIDs) != 1 || profiles[0].EquipmentIDs[0] != dumbbells.ID {
+		t.Errorf("profiles after delete are %+v, want only Dumbbells left", profiles)
+	}
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"fitness-framework-api/internal/models"
+	"fitness-framework-api/internal/store"
+)
+
+type exerciseListResponse struct {
+	Data       []models.Exercise `json:"data"`
+	Total      int64             `json:"total"`
+	Count      int               `json:"count"`
+	Next       string            `json:"next,omitempty"`
+	Prev       string            `json:"prev,omitempty"`
+	NextCursor string            `json:"nextCursor,omitempty"`
+	PrevCursor string            `json:"prevCursor,omitempty"`
+}
+
+func parsePageRequest(q url.Values) (store.PageRequest, error) {
+	page := store.PageRequest{Sort: store.DefaultSort}
+
+	searching := strings.TrimSpace(q.Get("q")) != ""
+	if searching {
+		page.Sort = store.SortRelevance
+	}
+	if sortParam := q.Get("sort"); sortParam != "" {
+		page.Desc = strings.HasPrefix(sortParam, "-")
+		page.Sort = strings.TrimPrefix(sortParam, "-")
+		if _, ok := store.SortFields[page.Sort]; !ok && page.Sort != store.SortRelevance {
+			return page, fmt.Errorf("unsupported sort field %q", page.Sort)
+		}
+	}
+	if page.Sort == store.SortRelevance && !searching {
+		return page, fmt.Errorf("sorting by relevance requires the q parameter")
+	}
+
+	var err error
+	if page.Limit, err = nonNegativeInt(q, "limit"); err != nil {
+		return page, err
+	}
+	if page.Offset, err = nonNegativeInt(q, "offset"); err != nil {
+		return page, err
+	}
+
+	if after := q.Get("cursor"); after != "" {
+		if page.After, err = store.DecodeCursor(after); err != nil {
+			return page, fmt.Errorf("invalid cursor parameter: %w", err)
+		}
+	}
+	if before := q.Get("before"); before != "" {
+		if page.Before, err = store.DecodeCursor(before); err != nil {
+			return page, fmt.Errorf("invalid before parameter: %w", err)
+		}
+	}
+
+	if page.After != nil && page.Before != nil {
+		return page, fmt.Errorf("cursor and before cannot be used together")
+	}
+	if (page.After != nil || page.Before != nil) && q.Has("offset") {
+		return page, fmt.Errorf("offset cannot be combined with cursor or before")
+	}
+	if (page.After != nil || page.Before != nil) && page.Sort == store.SortRelevance {
+		return page, fmt.Errorf("results sorted by relevance only support offset pagination")
+	}
+
+	return page, nil
+}
+
+func nonNegativeInt(q url.Values, name string) (int, error) {
+	raw := q.Get(name)
+	if raw == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%s must be a non-negative integer", name)
+	}
+	return n, nil
+}
+
+// positiveInt is nonNegativeInt for limits, where zero would mean no limit
+// in the stores.
+func positiveInt(q url.Values, name string) (int, error) {
+	n, err := strconv.Atoi(q.Get(name))
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%s must be a positive integer", name)
+	}
+	return n, nil
+}
+
+func newExerciseListResponse(r *http.Request, page store.PageRequest, result *store.ExercisePage) exerciseListResponse {
+	resp := exerciseListResponse{
+		Data:  result.Exercises,
+		Total: result.Total,
+		Count: len(result.Exercises),
+	}
+	if resp.Data == nil {
+		resp.Data = []models.Exercise{}
+	}
+
+	q := r.URL.Query()
+	if q.Has("offset") || page.Sort == store.SortRelevance {
+		if result.HasNext {
+			resp.Next = pageLink(r, "offset", strconv.Itoa(page.Offset+len(result.Exercises)))
+		}
+		if result.HasPrev {
+			resp.Prev = pageLink(r, "offset", strconv.Itoa(max(0, page.Offset-page.Limit)))
+		}
+		return resp
+	}
+
+	if len(result.Exercises) == 0 {
+		return resp
+	}
+	if result.HasNext {
+		resp.NextCursor = store.CursorFor(result.Exercises[len(result.Exercises)-1], page.Sort).Encode()
+		resp.Next = pageLink(r, "cursor", resp.NextCursor)
+	}
+	if result.HasPrev {
+		resp.PrevCursor = store.CursorFor(result.Exercises[0], page.Sort).Encode()
+		resp.Prev = pageLink(r, "before", resp.PrevCursor)
+	}
+	return resp
+}
+
+func pageLink(r *http.Request, key, value string) string {
+	q := r.URL.Query()
+	q.Del("cursor")
+	q.Del("before")
+	q.Del("offset")
+	q.Set(key, value)
+	return r.URL.Path + "?" + q.Encode()
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"fitness-framework-api/internal/auth"
+	"fitness-framework-api/internal/store"
+)
+
+const defaultSubstituteLimit = 10
+
+type substitutesResponse struct {
+	Data  []store.Substitute `json:"data"`
+	Count int                `json:"count"`
+}
+
+func (api *API) ExerciseSubstitutesHandler(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w, "GET, OPTIONS")
+
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusOK)
+		return
+	case http.MethodGet:
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	q := r.URL.Query()
+	opts := store.SubstituteOptions{
+		Available:        q["equipment"],
+		ExcludeEquipment: q["equipment_not"],
+	}
+	if v := q.Get("available_only"); v != "" {
+		var err error
+		if opts.AvailableOnly, err = strconv.ParseBool(v); err != nil {
+			writeError(w, http.StatusBadRequest, "available_only must be true or false")
+			return
+		}
+		if opts.AvailableOnly && opts.Available == nil && !q.Has("gym") {
+			writeError(w, http.StatusBadRequest, "available_only requires at least one equipment parameter or gym")
+			return
+		}
+	}
+	limit := defaultSubstituteLimit
+	if q.Has("limit") {
+		var err error
+		if limit, err = nonNegativeInt(q, "limit"); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	view, err := parseView(q, viewSummary)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	localizer, ok := api.localizer(w, r)
+	if !ok {
+		return
+	}
+	gym, ok := api.gymEquipment(w, r)
+	if !ok {
+		return
+	}
+	if gym != nil {
+		opts.Available = gym
+	}
+
+	exercise, ok := api.lookupExercise(w, r)
+	if !ok {
+		return
+	}
+
+	var filter store.ExerciseFilter
+	if identity, ok := auth.IdentityFromContext(r.Context()); ok {
+		filter.Owner = &identity.UserID
+	}
+	catalog, err := api.Store.GetExercises(r.Context(), filter)
+	if err != nil {
+		api.writeStoreError(w, "get exercises", err)
+		return
+	}
+	muscles, err := api.Store.GetOptions(r.Context(), store.OptionMuscle)
+	if err != nil {
+		api.writeStoreError(w, "get options", err)
+		return
+	}
+	opts.Expansions = store.Descendants(muscles)
+
+	substitutes := store.RankSubstitutes(*exercise, catalog, opts)
+	if limit > 0 && len(substitutes) > limit {
+		substitutes = substitutes[:limit]
+	}
+	if substitutes == nil {
+		substitutes = []store.Substitute{}
+	}
+	for i := range substitutes {
+		localizer.Exercise(&substitutes[i].Exercise)
+		applyView(&substitutes[i].Exercise, view)
+		api.setMediaURLs(&substitutes[i].Exercise)
+	}
+
+	writeJSON(w, http.StatusOK, substitutesResponse{Data: substitutes, Count: len(substitutes)})
+}
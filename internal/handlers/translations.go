@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"fitness-framework-api/internal/i18n"
+	"fitness-framework-api/internal/store"
+)
+
+func (api *API) TranslationsHandler(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w, "GET, OPTIONS")
+
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		locales, err := i18n.Locales(r.Context(), api.Store)
+		if err != nil {
+			api.writeStoreError(w, "get translations", err)
+			return
+		}
+		writeJSON(w, http.StatusOK, locales)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (api *API) TranslationHandler(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w, "GET, PUT, DELETE, OPTIONS")
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	locale, err := i18n.ParseLocale(r.PathValue("locale"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		f, err := i18n.Export(r.Context(), api.Store, locale)
+		if err != nil {
+			api.writeStoreError(w, "export translations", err)
+			return
+		}
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", locale+".json"))
+		writeJSON(w, http.StatusOK, f)
+	case http.MethodPut:
+		var f i18n.File
+		if err := decodeJSON(r, &f); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+			return
+		}
+		if f.Locale == "" {
+			f.Locale = locale
+		}
+		if bodyLocale, err := i18n.ParseLocale(f.Locale); err == nil && bodyLocale != locale {
+			writeError(w, http.StatusBadRequest, "validation failed", fieldError{Field: "locale", Message: fmt.Sprintf("does not match the locale %q in the path", locale)})
+			return
+		}
+		api.importTranslations(w, r, f)
+	case http.MethodDelete:
+		api.importTranslations(w, r, i18n.File{Locale: locale})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (api *API) importTranslations(w http.ResponseWriter, r *http.Request, f i18n.File) {
+	result, err := i18n.Import(r.Context(), api.Store, f)
+	var invalid *i18n.InvalidFileError
+	if errors.As(err, &invalid) {
+		details := make([]fieldError, len(invalid.Problems))
+		for i, p := range invalid.Problems {
+			details[i] = fieldError{Field: p.Field, Message: p.Message}
+		}
+		writeError(w, http.StatusBadRequest, "validation failed", details...)
+		return
+	}
+	if err != nil {
+		api.writeStoreError(w, "import translations", err)
+		return
+	}
+
+	if r.Method == http.MethodDelete {
+		api.audit(r, "translations.delete", "translations", result.Locale, "")
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	api.audit(r, "translations.import", "translations", result.Locale, "")
+	writeJSON(w, http.StatusOK, result)
+}
+
+// localizer returns the Localizer for the locales the request asks for,
+// or nil for English. It writes an error response and returns false if
+// the lang parameter is invalid.
+func (api *API) localizer(w http.ResponseWriter, r *http.Request) (*i18n.Localizer, bool) {
+	w.Header().Add("Vary", "Accept-Language")
+
+	prefs, err := i18n.Preferences(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+	if len(prefs) == 0 {
+		return nil, true
+	}
+
+	equipment, err := api.Store.GetOptions(r.Context(), store.OptionEquipment)
+	if err != nil {
+		api.writeStoreError(w, "get options", err)
+		return nil, false
+	}
+	muscles, err := api.Store.GetOptions(r.Context(), store.OptionMuscle)
+	if err != nil {
+		api.writeStoreError(w, "get options", err)
+		return nil, false
+	}
+	return i18n.NewLocalizer(prefs, equipment, muscles), true
+}
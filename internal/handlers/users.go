@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fitness-framework-api/internal/auth"
+	"fitness-framework-api/internal/models"
+)
+
+type roleInput struct {
+	Role models.Role `json:"role"`
+}
+
+type apiKeyInput struct {
+	Name string `json:"name"`
+}
+
+// createdAPIKey is the only response that contains the key itself.
+type createdAPIKey struct {
+	models.APIKey
+	Key string `json:"key"`
+}
+
+func (api *API) UsersHandler(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w, "GET, OPTIONS")
+
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		users, err := api.Users.ListUsers(r.Context())
+		if err != nil {
+			api.writeStoreError(w, "get users", err)
+			return
+		}
+		if users == nil {
+			users = []models.User{}
+		}
+		writeJSON(w, http.StatusOK, users)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// UserRoleHandler changes the role of a user. Access tokens the user
+// already holds keep their old role until they expire.
+func (api *API) UserRoleHandler(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w, "PUT, OPTIONS")
+
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusOK)
+	case http.MethodPut:
+		id, err := primitive.ObjectIDFromHex(r.PathValue("id"))
+		if err != nil {
+			writeError(w, http.StatusNotFound, "not found")
+			return
+		}
+		var in roleInput
+		if err := decodeJSON(r, &in); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+			return
+		}
+		if !in.Role.Valid() {
+			writeError(w, http.StatusBadRequest, "validation failed", fieldError{Field: "role", Message: fmt.Sprintf("must be one of %v", models.Roles)})
+			return
+		}
+
+		if err := api.Users.SetUserRole(r.Context(), id, in.Role); err != nil {
+			api.writeStoreError(w, "set user role", err)
+			return
+		}
+		user, err := api.Users.GetUser(r.Context(), id)
+		if err != nil {
+			api.writeStoreError(w, "get user", err)
+			return
+		}
+		api.audit(r, "user.role", "user", user.ID.Hex(), user.Email)
+		writeJSON(w, http.StatusOK, user)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (api *API) APIKeysHandler(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w, "GET, POST, OPTIONS")
+
+	identity, _ := auth.IdentityFromContext(r.Context())
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		keys, err := api.Users.ListAPIKeys(r.Context(), identity.UserID)
+		if err != nil {
+			api.writeStoreError(w, "get API keys", err)
+			return
+		}
+		if keys == nil {
+			keys = []models.APIKey{}
+		}
+		writeJSON(w, http.StatusOK, keys)
+	case http.MethodPost:
+		if identity.SessionID.IsZero() {
+			writeError(w, http.StatusForbidden, "API keys cannot create API keys; log in instead")
+			return
+		}
+		var in apiKeyInput
+		if err := decodeJSON(r, &in); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+			return
+		}
+		in.Name = strings.TrimSpace(in.Name)
+		if in.Name == "" {
+			writeError(w, http.StatusBadRequest, "validation failed", fieldError{Field: "name", Message: "name is required"})
+			return
+		}
+
+		key, hash, prefix := auth.NewAPIKey()
+		created := createdAPIKey{
+			APIKey: models.APIKey{
+				UserID:    identity.UserID,
+				Name:      in.Name,
+				Prefix:    prefix,
+				KeyHash:   hash,
+				CreatedAt: time.Now().UTC(),
+			},
+			Key: key,
+		}
+		if err := api.Users.CreateAPIKey(r.Context(), &created.APIKey); err != nil {
+			api.writeStoreError(w, "create API key", err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, created)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// APIKeyHandler revokes an API key of the caller. Revoked keys stay listed.
+func (api *API) APIKeyHandler(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w, "DELETE, OPTIONS")
+
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusOK)
+	case http.MethodDelete:
+		identity, _ := auth.IdentityFromContext(r.Context())
+		if identity.SessionID.IsZero() {
+			writeError(w, http.StatusForbidden, "API keys cannot revoke API keys; log in instead")
+			return
+		}
+		id, err := primitive.ObjectIDFromHex(r.PathValue("keyId"))
+		if err != nil {
+			writeError(w, http.StatusNotFound, "not found")
+			return
+		}
+		if err := api.Users.RevokeAPIKey(r.Context(), identity.UserID, id, time.Now().UTC()); err != nil {
+			api.writeStoreError(w, "revoke API key", err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
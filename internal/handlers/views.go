@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"fmt"
+	"net/url"
+
+	"fitness-framework-api/internal/models"
+)
+
+const (
+	viewSummary = "summary"
+	viewFull    = "full"
+)
+
+func parseView(q url.Values, fallback string) (string, error) {
+	switch view := q.Get("view"); view {
+	case "":
+		return fallback, nil
+	case viewSummary, viewFull:
+		return view, nil
+	default:
+		return "", fmt.Errorf("unsupported view %q", view)
+	}
+}
+
+func applyView(ex *models.Exercise, view string) {
+	if view != viewSummary {
+		return
+	}
+	ex.Instructions = nil
+	ex.Cues = nil
+	ex.CommonMistakes = nil
+	ex.SafetyNotes = nil
+}
@@ -0,0 +1,325 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fitness-framework-api/internal/auth"
+	"fitness-framework-api/internal/constants"
+	"fitness-framework-api/internal/models"
+	"fitness-framework-api/internal/store"
+)
+
+const (
+	defaultWorkoutLimit = 100
+	maxWorkoutLimit     = 1000
+)
+
+type workoutInput struct {
+	StartedAt *time.Time               `json:"startedAt"`
+	EndedAt   *time.Time               `json:"endedAt"`
+	Notes     string                   `json:"notes"`
+	Exercises []models.WorkoutExercise `json:"exercises"`
+}
+
+// inputFromWorkout leaves out the exercises, which are kept by the caller
+// when a patch does not replace them.
+func inputFromWorkout(workout models.Workout) workoutInput {
+	return workoutInput{StartedAt: &workout.StartedAt, EndedAt: workout.EndedAt, Notes: workout.Notes}
+}
+
+func (api *API) WorkoutsHandler(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w, "GET, POST, OPTIONS")
+
+	identity, _ := auth.IdentityFromContext(r.Context())
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		filter, err := parseWorkoutFilter(r, identity.UserID)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		workouts, err := api.Workouts.ListWorkouts(r.Context(), filter)
+		if err != nil {
+			api.writeStoreError(w, "get workouts", err)
+			return
+		}
+		if workouts == nil {
+			workouts = []models.Workout{}
+		}
+		if err := api.setWorkoutExerciseNames(r.Context(), workouts); err != nil {
+			api.writeStoreError(w, "get exercises", err)
+			return
+		}
+		writeJSON(w, http.StatusOK, workouts)
+	case http.MethodPost:
+		var in workoutInput
+		if err := decodeJSON(r, &in); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+			return
+		}
+		now := time.Now().UTC()
+		if in.StartedAt == nil {
+			in.StartedAt = &now
+		}
+		workout := models.Workout{UserID: identity.UserID, CreatedAt: now, UpdatedAt: now}
+		if !api.applyWorkoutInput(w, r.Context(), in, &workout) {
+			return
+		}
+		if err := api.Workouts.CreateWorkout(r.Context(), &workout); err != nil {
+			api.writeStoreError(w, "create workout", err)
+			return
+		}
+		api.writeWorkout(w, r.Context(), http.StatusCreated, workout)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (api *API) WorkoutHandler(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w, "GET, PUT, PATCH, DELETE, OPTIONS")
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	identity, _ := auth.IdentityFromContext(r.Context())
+	id, err := primitive.ObjectIDFromHex(r.PathValue("workoutId"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	current, err := api.Workouts.GetWorkout(r.Context(), identity.UserID, id)
+	if err != nil {
+		api.writeStoreError(w, "get workout", err)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		api.writeWorkout(w, r.Context(), http.StatusOK, *current)
+	case http.MethodPut, http.MethodPatch:
+		var in workoutInput
+		if r.Method == http.MethodPatch {
+			in = inputFromWorkout(*current)
+		}
+		if err := decodeJSON(r, &in); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+			return
+		}
+		if r.Method == http.MethodPatch && in.Exercises == nil {
+			in.Exercises = current.Exercises
+		}
+		workout := *current
+		workout.UpdatedAt = time.Now().UTC()
+		if !api.applyWorkoutInput(w, r.Context(), in, &workout) {
+			return
+		}
+		if err := api.Workouts.UpdateWorkout(r.Context(), workout); err != nil {
+			api.writeStoreError(w, "update workout", err)
+			return
+		}
+		api.writeWorkout(w, r.Context(), http.StatusOK, workout)
+	case http.MethodDelete:
+		if err := api.Workouts.DeleteWorkout(r.Context(), identity.UserID, current.ID); err != nil {
+			api.writeStoreError(w, "delete workout", err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func parseWorkoutFilter(r *http.Request, userID primitive.ObjectID) (store.WorkoutFilter, error) {
+	q := r.URL.Query()
+	filter := store.WorkoutFilter{UserID: userID, Limit: defaultWorkoutLimit}
+	for name, bound := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		if v := q.Get(name); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return filter, fmt.Errorf("invalid %s parameter: %q is not an RFC 3339 time", name, v)
+			}
+			*bound = &t
+		}
+	}
+	if v := q.Get("exercise"); v != "" {
+		id, err := primitive.ObjectIDFromHex(v)
+		if err != nil {
+			return filter, fmt.Errorf("invalid exercise parameter: %q is not an exercise id", v)
+		}
+		filter.ExerciseID = &id
+	}
+	if q.Has("limit") {
+		limit, err := positiveInt(q, "limit")
+		if err != nil {
+			return filter, err
+		}
+		filter.Limit = min(limit, maxWorkoutLimit)
+	}
+	return filter, nil
+}
+
+// applyWorkoutInput validates in and copies it to workout. Exercises must
+// be catalog exercises or custom exercises of the workout's user, unless
+// workout already refers to them: they may have been deleted since.
+func (api *API) applyWorkoutInput(w http.ResponseWriter, ctx context.Context, in workoutInput, workout *models.Workout) bool {
+	known := make(map[primitive.ObjectID]bool, len(workout.Exercises))
+	for _, entry := range workout.Exercises {
+		known[entry.ExerciseID] = true
+	}
+	var ids []primitive.ObjectID
+	for _, entry := range in.Exercises {
+		if !known[entry.ExerciseID] && !entry.ExerciseID.IsZero() && !slices.Contains(ids, entry.ExerciseID) {
+			ids = append(ids, entry.ExerciseID)
+		}
+	}
+	exercises, err := api.exercisesByID(ctx, ids)
+	if err != nil {
+		api.writeStoreError(w, "get exercises", err)
+		return false
+	}
+
+	var problems []fieldError
+	if in.StartedAt == nil {
+		problems = append(problems, fieldError{Field: "startedAt", Message: "startedAt is required"})
+	} else {
+		workout.StartedAt = in.StartedAt.UTC()
+	}
+	workout.EndedAt = nil
+	if in.EndedAt != nil {
+		ended := in.EndedAt.UTC()
+		workout.EndedAt = &ended
+		if in.StartedAt != nil && ended.Before(workout.StartedAt) {
+			problems = append(problems, fieldError{Field: "endedAt", Message: "endedAt must not be before startedAt"})
+		}
+	}
+	workout.Notes = strings.TrimSpace(in.Notes)
+
+	workout.Exercises = make([]models.WorkoutExercise, 0, len(in.Exercises))
+	for i, entry := range in.Exercises {
+		field := fmt.Sprintf("exercises[%d]", i)
+		exercise, found := exercises[entry.ExerciseID]
+		switch {
+		case entry.ExerciseID.IsZero():
+			problems = append(problems, fieldError{Field: field + ".exerciseId", Message: "exerciseId is required"})
+		case known[entry.ExerciseID]:
+		case !found || exercise.OwnerID != nil && *exercise.OwnerID != workout.UserID:
+			problems = append(problems, fieldError{Field: field + ".exerciseId", Message: fmt.Sprintf("%q is not an exercise id", entry.ExerciseID.Hex())})
+		}
+
+		sets := make([]models.WorkoutSet, 0, len(entry.Sets))
+		for j, set := range entry.Sets {
+			problems = append(problems, validateWorkoutSet(fmt.Sprintf("%s.sets[%d]", field, j), &set)...)
+			sets = append(sets, set)
+		}
+		workout.Exercises = append(workout.Exercises, models.WorkoutExercise{
+			ExerciseID: entry.ExerciseID,
+			Notes:      strings.TrimSpace(entry.Notes),
+			Sets:       sets,
+		})
+	}
+
+	if len(problems) > 0 {
+		writeError(w, http.StatusBadRequest, "validation failed", problems...)
+		return false
+	}
+	return true
+}
+
+// validateWorkoutSet normalizes set and returns its problems. Sets are
+// working sets unless a type is given.
+func validateWorkoutSet(field string, set *models.WorkoutSet) []fieldError {
+	var problems []fieldError
+	set.Type = strings.ToLower(strings.TrimSpace(set.Type))
+	if set.Type == "" {
+		set.Type = constants.SetTypeWorking
+	} else if !constants.IsValidSetType(set.Type) {
+		problems = append(problems, fieldError{Field: field + ".type", Message: fmt.Sprintf("type must be one of %s", strings.Join(constants.AllSetTypes, ", "))})
+	}
+	if set.Reps != nil && *set.Reps < 0 {
+		problems = append(problems, fieldError{Field: field + ".reps", Message: "reps must not be negative"})
+	}
+	if set.Weight != nil && *set.Weight < 0 {
+		problems = append(problems, fieldError{Field: field + ".weight", Message: "weight must not be negative"})
+	}
+	set.Unit = strings.ToLower(strings.TrimSpace(set.Unit))
+	switch {
+	case set.Unit == "" && set.Weight != nil:
+		problems = append(problems, fieldError{Field: field + ".unit", Message: "unit is required with weight"})
+	case set.Unit != "" && !constants.IsValidWeightUnit(set.Unit):
+		problems = append(problems, fieldError{Field: field + ".unit", Message: fmt.Sprintf("unit must be one of %s", strings.Join(constants.AllWeightUnits, ", "))})
+	}
+	if set.RPE != nil && (*set.RPE < 1 || *set.RPE > 10) {
+		problems = append(problems, fieldError{Field: field + ".rpe", Message: "rpe must be between 1 and 10"})
+	}
+	if set.RIR != nil && *set.RIR < 0 {
+		problems = append(problems, fieldError{Field: field + ".rir", Message: "rir must not be negative"})
+	}
+	if set.RestSeconds != nil && *set.RestSeconds < 0 {
+		problems = append(problems, fieldError{Field: field + ".restSeconds", Message: "restSeconds must not be negative"})
+	}
+	return problems
+}
+
+func (api *API) writeWorkout(w http.ResponseWriter, ctx context.Context, status int, workout models.Workout) {
+	workouts := []models.Workout{workout}
+	if err := api.setWorkoutExerciseNames(ctx, workouts); err != nil {
+		api.writeStoreError(w, "get exercises", err)
+		return
+	}
+	if status == http.StatusCreated {
+		w.Header().Set("Location", "/api/workouts/"+workout.ID.Hex())
+	}
+	writeJSON(w, status, workouts[0])
+}
+
+// setWorkoutExerciseNames fills in the current names of the exercises of
+// workouts. Exercises deleted since keep an empty name.
+func (api *API) setWorkoutExerciseNames(ctx context.Context, workouts []models.Workout) error {
+	var ids []primitive.ObjectID
+	for _, workout := range workouts {
+		for _, entry := range workout.Exercises {
+			if !slices.Contains(ids, entry.ExerciseID) {
+				ids = append(ids, entry.ExerciseID)
+			}
+		}
+	}
+	exercises, err := api.exercisesByID(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for i := range workouts {
+		for j := range workouts[i].Exercises {
+			entry := &workouts[i].Exercises[j]
+			entry.Name = exercises[entry.ExerciseID].Name
+		}
+	}
+	return nil
+}
+
+// exercisesByID loads the exercises with the given IDs, of every owner, in
+// a single query.
+func (api *API) exercisesByID(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]models.Exercise, error) {
+	byID := make(map[primitive.ObjectID]models.Exercise, len(ids))
+	if len(ids) == 0 {
+		return byID, nil
+	}
+	exercises, err := api.Store.GetExercises(ctx, store.ExerciseFilter{IDs: ids, AllOwners: true})
+	if err != nil {
+		return nil, err
+	}
+	for _, exercise := range exercises {
+		byID[exercise.ID] = exercise
+	}
+	return byID, nil
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fitness-framework-api/internal/auth"
+	"fitness-framework-api/internal/models"
+)
+
+func TestListWorkoutsLimit(t *testing.T) {
+	api, s := newTestAPI(t)
+	user := auth.Identity{UserID: primitive.NewObjectID(), Role: models.RoleUser}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range maxWorkoutLimit + 1 {
+		workout := &models.Workout{UserID: user.UserID, StartedAt: start.Add(time.Duration(i) * time.Hour)}
+		if err := s.CreateWorkout(context.Background(), workout); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		query  string
+		status int
+		count  int
+	}{
+		{"", http.StatusOK, defaultWorkoutLimit},
+		{"?limit=3", http.StatusOK, 3},
+		{"?limit=5000", http.StatusOK, maxWorkoutLimit},
+		{"?limit=0", http.StatusBadRequest, 0},
+		{"?limit=-1", http.StatusBadRequest, 0},
+		{"?limit=", http.StatusBadRequest, 0},
+	}
+	for _, tt := range tests {
+		r := as(newRequest(t, http.MethodGet, "/api/workouts"+tt.query, nil), user)
+		rec := serve("/api/workouts", api.WorkoutsHandler, r)
+		if rec.Code != tt.status {
+			t.Errorf("GET /api/workouts%s: status %d, want %d: %s", tt.query, rec.Code, tt.status, rec.Body)
+			continue
+		}
+		if tt.status != http.StatusOK {
+			continue
+		}
+		if workouts := decodeBody[[]models.Workout](t, rec); len(workouts) != tt.count {
+			t.Errorf("GET /api/workouts%s: %d workouts, want %d", tt.query, len(workouts), tt.count)
+		}
+	}
+}
@@ -0,0 +1,307 @@
+package i18n
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"fitness-framework-api/internal/models"
+	"fitness-framework-api/internal/store"
+)
+
+// File holds every translation of one locale, keyed by the English names
+// of exercises, equipment and muscles.
+type File struct {
+	Locale    string                                `json:"locale" yaml:"locale"`
+	Exercises map[string]models.ExerciseTranslation `json:"exercises" yaml:"exercises"`
+	Equipment map[string]models.OptionTranslation   `json:"equipment" yaml:"equipment"`
+	Muscles   map[string]models.OptionTranslation   `json:"muscles" yaml:"muscles"`
+}
+
+type LocaleSummary struct {
+	Locale    string `json:"locale"`
+	Exercises int    `json:"exercises"`
+	Equipment int    `json:"equipment"`
+	Muscles   int    `json:"muscles"`
+}
+
+type ImportResult struct {
+	LocaleSummary
+	Updated int `json:"updated"`
+}
+
+type Problem struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type InvalidFileError struct {
+	Problems []Problem
+}
+
+func (e *InvalidFileError) Error() string {
+	messages := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		messages[i] = p.Field + ": " + p.Message
+	}
+	return "invalid translation file: " + strings.Join(messages, "; ")
+}
+
+// Locales summarizes how much of the catalog is translated per locale.
+func Locales(ctx context.Context, s store.ExerciseStore) ([]LocaleSummary, error) {
+	exercises, equipment, muscles, err := loadCatalog(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make(map[string]*LocaleSummary)
+	count := func(locale string, field func(*LocaleSummary) *int) {
+		if summaries[locale] == nil {
+			summaries[locale] = &LocaleSummary{Locale: locale}
+		}
+		*field(summaries[locale])++
+	}
+	for _, ex := range exercises {
+		for locale := range ex.Translations {
+			count(locale, func(s *LocaleSummary) *int { return &s.Exercises })
+		}
+	}
+	for _, option := range equipment {
+		for locale := range option.Translations {
+			count(locale, func(s *LocaleSummary) *int { return &s.Equipment })
+		}
+	}
+	for _, option := range muscles {
+		for locale := range option.Translations {
+			count(locale, func(s *LocaleSummary) *int { return &s.Muscles })
+		}
+	}
+
+	out := make([]LocaleSummary, 0, len(summaries))
+	for _, locale := range slices.Sorted(maps.Keys(summaries)) {
+		out = append(out, *summaries[locale])
+	}
+	return out, nil
+}
+
+func Export(ctx context.Context, s store.ExerciseStore, locale string) (*File, error) {
+	exercises, equipment, muscles, err := loadCatalog(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &File{
+		Locale:    locale,
+		Exercises: make(map[string]models.ExerciseTranslation),
+		Equipment: exportOptions(equipment, locale),
+		Muscles:   exportOptions(muscles, locale),
+	}
+	for _, ex := range exercises {
+		if t, ok := ex.Translations[locale]; ok {
+			f.Exercises[ex.Name] = t
+		}
+	}
+	return f, nil
+}
+
+// Import replaces the translations of f.Locale with the contents of f.
+// Entries that are not in the file lose their translation for the locale.
+// Nothing is changed if the file refers to unknown names or has empty
+// lines; the problems are returned as an *InvalidFileError.
+func Import(ctx context.Context, s store.ExerciseStore, f File) (*ImportResult, error) {
+	locale, err := ParseLocale(f.Locale)
+	if err != nil {
+		return nil, &InvalidFileError{Problems: []Problem{{Field: "locale", Message: err.Error()}}}
+	}
+	if locale == DefaultLocale {
+		return nil, &InvalidFileError{Problems: []Problem{{Field: "locale", Message: "the catalog is written in English; there is nothing to translate"}}}
+	}
+
+	exercises, equipment, muscles, err := loadCatalog(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	exerciseNames := make([]string, len(exercises))
+	for i, ex := range exercises {
+		exerciseNames[i] = ex.Name
+	}
+	problems := checkKeys("exercises", f.Exercises, exerciseNames)
+	problems = append(problems, checkKeys("equipment", f.Equipment, optionNames(equipment))...)
+	problems = append(problems, checkKeys("muscles", f.Muscles, optionNames(muscles))...)
+	for name, t := range f.Exercises {
+		for _, text := range []struct {
+			field string
+			lines []string
+		}{
+			{"instructions", t.Instructions},
+			{"cues", t.Cues},
+			{"commonMistakes", t.CommonMistakes},
+			{"safetyNotes", t.SafetyNotes},
+		} {
+			for i, line := range text.lines {
+				if strings.TrimSpace(line) == "" {
+					problems = append(problems, Problem{Field: fmt.Sprintf("exercises[%q].%s[%d]", name, text.field, i), Message: "must not be empty"})
+				}
+			}
+		}
+	}
+	if len(problems) > 0 {
+		slices.SortFunc(problems, func(a, b Problem) int { return strings.Compare(a.Field, b.Field) })
+		return nil, &InvalidFileError{Problems: problems}
+	}
+
+	result := &ImportResult{LocaleSummary: LocaleSummary{Locale: locale, Exercises: len(f.Exercises), Equipment: len(f.Equipment), Muscles: len(f.Muscles)}}
+	for _, ex := range exercises {
+		translations, changed := replaceTranslation(ex.Translations, locale, f.Exercises, ex.Name)
+		if !changed {
+			continue
+		}
+		ex.Translations = translations
+		if err := s.UpdateExercise(ctx, ex); err != nil {
+			return result, fmt.Errorf("failed to update translations of exercise %q: %w", ex.Name, err)
+		}
+		result.Updated++
+	}
+	for _, kind := range []struct {
+		kind         store.OptionKind
+		opts         []models.Option
+		translations map[string]models.OptionTranslation
+	}{
+		{store.OptionEquipment, equipment, f.Equipment},
+		{store.OptionMuscle, muscles, f.Muscles},
+	} {
+		for _, option := range kind.opts {
+			translations, changed := replaceTranslation(option.Translations, locale, kind.translations, option.Name)
+			if !changed {
+				continue
+			}
+			option.Translations = translations
+			if err := s.UpdateOption(ctx, kind.kind, option); err != nil {
+				return result, fmt.Errorf("failed to update translations of %s option %q: %w", kind.kind, option.Name, err)
+			}
+			result.Updated++
+		}
+	}
+	return result, nil
+}
+
+// LoadDir reads every JSON and YAML translation file in dir. Files without
+// a locale take it from their name, e.g. de.json. A missing directory
+// holds no files.
+func LoadDir(dir string) ([]File, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read translations directory: %w", err)
+	}
+
+	var files []File
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || (ext != ".json" && ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read translation file %s: %w", entry.Name(), err)
+		}
+		var f File
+		if ext == ".json" {
+			err = json.Unmarshal(data, &f)
+		} else {
+			err = yaml.Unmarshal(data, &f)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse translation file %s: %w", entry.Name(), err)
+		}
+		if f.Locale == "" {
+			f.Locale = strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+func loadCatalog(ctx context.Context, s store.ExerciseStore) ([]models.Exercise, []models.Option, []models.Option, error) {
+	exercises, err := s.GetExercises(ctx, store.ExerciseFilter{})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	equipment, err := s.GetOptions(ctx, store.OptionEquipment)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	muscles, err := s.GetOptions(ctx, store.OptionMuscle)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return exercises, equipment, muscles, nil
+}
+
+func exportOptions(opts []models.Option, locale string) map[string]models.OptionTranslation {
+	out := make(map[string]models.OptionTranslation)
+	for _, option := range opts {
+		if t, ok := option.Translations[locale]; ok {
+			out[option.Name] = t
+		}
+	}
+	return out
+}
+
+func optionNames(opts []models.Option) []string {
+	names := make([]string, len(opts))
+	for i, option := range opts {
+		names[i] = option.Name
+	}
+	return names
+}
+
+func checkKeys[T any](field string, entries map[string]T, known []string) []Problem {
+	var problems []Problem
+	for name := range entries {
+		if !slices.Contains(known, name) {
+			message := "unknown name"
+			if i := slices.IndexFunc(known, func(k string) bool { return strings.EqualFold(k, name) }); i >= 0 {
+				message = fmt.Sprintf("unknown name, did you mean %q?", known[i])
+			}
+			problems = append(problems, Problem{Field: fmt.Sprintf("%s[%q]", field, name), Message: message})
+		}
+	}
+	return problems
+}
+
+// replaceTranslation returns a copy of current with the translation for
+// locale set from entries, or removed if entries has none for name.
+func replaceTranslation[T any](current map[string]T, locale string, entries map[string]T, name string) (map[string]T, bool) {
+	old, had := current[locale]
+	updated, has := entries[name]
+	if !had && !has || had && has && reflect.DeepEqual(old, updated) {
+		return current, false
+	}
+
+	translations := maps.Clone(current)
+	if translations == nil {
+		translations = make(map[string]T)
+	}
+	if has {
+		translations[locale] = updated
+	} else {
+		delete(translations, locale)
+	}
+	if len(translations) == 0 {
+		translations = nil
+	}
+	return translations, true
+}
@@ -0,0 +1,151 @@
+package i18n
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"golang.org/x/text/language"
+
+	"fitness-framework-api/internal/models"
+)
+
+// DefaultLocale is the language of the catalog itself. It has no
+// translations; everything else falls back to it.
+const DefaultLocale = "en"
+
+// ParseLocale validates a BCP 47 language tag and returns its canonical
+// form, e.g. "pt-br" becomes "pt-BR".
+func ParseLocale(s string) (string, error) {
+	tag, err := language.Parse(strings.TrimSpace(s))
+	if err != nil || tag == language.Und {
+		return "", fmt.Errorf("invalid locale %q", s)
+	}
+	return tag.String(), nil
+}
+
+// Preferences returns the locales the request asks for, most preferred
+// first. The lang query parameter takes precedence over the
+// Accept-Language header. Each locale is followed by its more general
+// forms, so "pt-BR" also accepts "pt". The list ends before English, since
+// English is the fallback anyway.
+func Preferences(r *http.Request) ([]string, error) {
+	var tags []language.Tag
+	if lang := r.URL.Query().Get("lang"); lang != "" {
+		locale, err := ParseLocale(lang)
+		if err != nil {
+			return nil, fmt.Errorf("invalid lang parameter: %w", err)
+		}
+		tags = []language.Tag{language.Make(locale)}
+	} else if header := r.Header.Get("Accept-Language"); header != "" {
+		// A malformed header is treated like a missing one.
+		tags, _, _ = language.ParseAcceptLanguage(header)
+	}
+
+	var prefs []string
+	for _, tag := range tags {
+		for ; tag != language.Und; tag = tag.Parent() {
+			if tag.String() == DefaultLocale {
+				return prefs, nil
+			}
+			if !slices.Contains(prefs, tag.String()) {
+				prefs = append(prefs, tag.String())
+			}
+		}
+	}
+	return prefs, nil
+}
+
+// Localizer replaces the English text of exercises and options with their
+// translations for a list of preferred locales. A nil Localizer leaves
+// everything in English.
+type Localizer struct {
+	prefs     []string
+	equipment map[string]models.Option
+	muscles   map[string]models.Option
+}
+
+// NewLocalizer returns a Localizer for prefs that translates equipment and
+// muscle names with the given options. It returns nil if prefs is empty.
+func NewLocalizer(prefs []string, equipment, muscles []models.Option) *Localizer {
+	if len(prefs) == 0 {
+		return nil
+	}
+	return &Localizer{prefs: prefs, equipment: byName(equipment), muscles: byName(muscles)}
+}
+
+func (l *Localizer) Exercise(ex *models.Exercise) {
+	if l == nil {
+		return
+	}
+
+	ex.Name = pick(l.prefs, ex.Translations, ex.Name, func(t models.ExerciseTranslation) string { return t.Name })
+	ex.Description = pick(l.prefs, ex.Translations, ex.Description, func(t models.ExerciseTranslation) string { return t.Description })
+	for _, text := range []struct {
+		lines *[]string
+		get   func(models.ExerciseTranslation) []string
+	}{
+		{&ex.Instructions, func(t models.ExerciseTranslation) []string { return t.Instructions }},
+		{&ex.Cues, func(t models.ExerciseTranslation) []string { return t.Cues }},
+		{&ex.CommonMistakes, func(t models.ExerciseTranslation) []string { return t.CommonMistakes }},
+		{&ex.SafetyNotes, func(t models.ExerciseTranslation) []string { return t.SafetyNotes }},
+	} {
+		*text.lines = pick(l.prefs, ex.Translations, *text.lines, text.get)
+	}
+
+	ex.Equipment = l.names(l.equipment, ex.Equipment)
+	ex.Muscles = l.names(l.muscles, ex.Muscles)
+	targets := make([]models.MuscleTarget, len(ex.MuscleTargets))
+	for i, target := range ex.MuscleTargets {
+		target.Muscle = l.name(l.muscles, target.Muscle)
+		targets[i] = target
+	}
+	ex.MuscleTargets = targets
+}
+
+func (l *Localizer) Option(option *models.Option) {
+	if l == nil {
+		return
+	}
+	option.Name = pick(l.prefs, option.Translations, option.Name, func(t models.OptionTranslation) string { return t.Name })
+	option.Description = pick(l.prefs, option.Translations, option.Description, func(t models.OptionTranslation) string { return t.Description })
+}
+
+func (l *Localizer) names(opts map[string]models.Option, names []string) []string {
+	if names == nil {
+		return nil
+	}
+	out := make([]string, len(names))
+	for i, name := range names {
+		out[i] = l.name(opts, name)
+	}
+	return out
+}
+
+func (l *Localizer) name(opts map[string]models.Option, name string) string {
+	option, ok := opts[strings.ToLower(name)]
+	if !ok {
+		return name
+	}
+	return pick(l.prefs, option.Translations, name, func(t models.OptionTranslation) string { return t.Name })
+}
+
+// pick returns the value of the first preferred locale that translates the
+// field, or fallback if none does.
+func pick[T any, V string | []string](prefs []string, translations map[string]T, fallback V, get func(T) V) V {
+	for _, locale := range prefs {
+		if t, ok := translations[locale]; ok && len(get(t)) > 0 {
+			return get(t)
+		}
+	}
+	return fallback
+}
+
+func byName(opts []models.Option) map[string]models.Option {
+	m := make(map[string]models.Option, len(opts))
+	for _, option := range opts {
+		m[strings.ToLower(option.Name)] = option
+	}
+	return m
+}
@@ -0,0 +1,142 @@
+package media
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"net/http"
+
+	_ "image/gif"
+	_ "image/png"
+)
+
+const (
+	KindImage = "image"
+	KindGIF   = "gif"
+	KindVideo = "video"
+)
+
+const (
+	MaxImageBytes  = 10 << 20
+	MaxVideoBytes  = 50 << 20
+	ThumbnailWidth = 320
+)
+
+// MaxImagePixels bounds the dimensions of uploaded images. A small file
+// can declare huge dimensions, and decoding it allocates memory for every
+// pixel.
+const MaxImagePixels = 40_000_000
+
+var ErrUnsupportedType = errors.New("unsupported media type")
+
+var ErrTooManyPixels = fmt.Errorf("images are limited to %d megapixels", MaxImagePixels/1_000_000)
+
+var allowedTypes = map[string]struct {
+	kind string
+	ext  string
+}{
+	"image/jpeg": {KindImage, ".jpg"},
+	"image/png":  {KindImage, ".png"},
+	"image/webp": {KindImage, ".webp"},
+	"image/gif":  {KindGIF, ".gif"},
+	"video/mp4":  {KindVideo, ".mp4"},
+	"video/webm": {KindVideo, ".webm"},
+}
+
+type Info struct {
+	ContentType string
+	Kind        string
+	Ext         string
+	Width       int
+	Height      int
+}
+
+// Inspect sniffs the content type from the file contents rather than trusting
+// the client, and reads image dimensions when the format is decodable.
+func Inspect(data []byte) (Info, error) {
+	contentType := http.DetectContentType(data)
+	t, ok := allowedTypes[contentType]
+	if !ok {
+		return Info{}, fmt.Errorf("%w: %s", ErrUnsupportedType, contentType)
+	}
+
+	info := Info{ContentType: contentType, Kind: t.kind, Ext: t.ext}
+	if cfg, _, err := image.DecodeConfig(bytes.NewReader(data)); err == nil {
+		info.Width, info.Height = cfg.Width, cfg.Height
+	}
+	return info, nil
+}
+
+// Pixels returns the number of pixels of an image, or zero if its
+// dimensions are unknown.
+func (info Info) Pixels() int64 {
+	return int64(info.Width) * int64(info.Height)
+}
+
+func MaxBytes(kind string) int64 {
+	if kind == KindVideo {
+		return MaxVideoBytes
+	}
+	return MaxImageBytes
+}
+
+// Thumbnail scales a JPEG, PNG or GIF (first frame) down to ThumbnailWidth
+// and encodes it as JPEG. Images above MaxImagePixels are not decoded.
+func Thumbnail(data []byte) ([]byte, int, int, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to decode image: %w", err)
+	}
+	if int64(cfg.Width)*int64(cfg.Height) > MaxImagePixels {
+		return nil, 0, 0, ErrTooManyPixels
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width == 0 || height == 0 {
+		return nil, 0, 0, fmt.Errorf("image has no pixels")
+	}
+	if width > ThumbnailWidth {
+		height = max(1, height*ThumbnailWidth/width)
+		width = ThumbnailWidth
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*bounds.Dy()/height)
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := max(x0+1, bounds.Min.X+(x+1)*bounds.Dx()/width)
+			dst.Set(x, y, average(src, x0, y0, x1, y1))
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 80}); err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+	return buf.Bytes(), width, height, nil
+}
+
+func average(src image.Image, x0, y0, x1, y1 int) color.Color {
+	var r, g, b, a, n uint64
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			pr, pg, pb, pa := src.At(x, y).RGBA()
+			r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+			n++
+		}
+	}
+	// JPEG has no alpha channel, so composite transparent pixels onto white.
+	white := 0xffff - a/n
+	return color.RGBA64{R: uint16(r/n + white), G: uint16(g/n + white), B: uint16(b/n + white), A: 0xffff}
+}
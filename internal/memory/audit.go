@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fitness-framework-api/internal/models"
+	"fitness-framework-api/internal/store"
+)
+
+var _ store.AuditStore = (*Store)(nil)
+
+func (s *Store) RecordAudit(ctx context.Context, event *models.AuditEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if event.ID.IsZero() {
+		event.ID = primitive.NewObjectID()
+	}
+	s.audit = append(s.audit, *event)
+	return nil
+}
+
+func (s *Store) ListAudit(ctx context.Context, filter store.AuditFilter) ([]models.AuditEvent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var events []models.AuditEvent
+	for i := len(s.audit) - 1; i >= 0; i-- {
+		if filter.Limit > 0 && len(events) == filter.Limit {
+			break
+		}
+		if filter.Matches(s.audit[i]) {
+			events = append(events, s.audit[i])
+		}
+	}
+	return events, nil
+}
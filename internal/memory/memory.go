@@ -0,0 +1,223 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fitness-framework-api/internal/models"
+	"fitness-framework-api/internal/slug"
+	"fitness-framework-api/internal/store"
+)
+
+var _ store.ExerciseStore = (*Store)(nil)
+
+type Store struct {
+	mu        sync.RWMutex
+	exercises []models.Exercise
+	options   map[store.OptionKind][]models.Option
+	users     []models.User
+	sessions  []models.Session
+	apiKeys   []models.APIKey
+	audit     []models.AuditEvent
+	profiles  []models.EquipmentProfile
+	workouts  []models.Workout
+}
+
+func NewStore() *Store {
+	return &Store{options: make(map[store.OptionKind][]models.Option)}
+}
+
+func (s *Store) Close(ctx context.Context) error {
+	return nil
+}
+
+func (s *Store) CreateExercise(ctx context.Context, exercise *models.Exercise) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	exercise.Slug = slug.Make(exercise.Name)
+	if s.nameTaken(*exercise) {
+		return store.ErrDuplicate
+	}
+	if err := s.resolveOptionRefs(exercise); err != nil {
+		return err
+	}
+	if exercise.ID.IsZero() {
+		exercise.ID = primitive.NewObjectID()
+	}
+	s.exercises = append(s.exercises, cloneExercise(*exercise))
+	return nil
+}
+
+func (s *Store) UpdateExercise(ctx context.Context, exercise models.Exercise) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.indexOf(exercise.ID)
+	if i < 0 {
+		return store.ErrNotFound
+	}
+	exercise.Slug = slug.Make(exercise.Name)
+	if s.nameTaken(exercise) {
+		return store.ErrDuplicate
+	}
+	if err := s.resolveOptionRefs(&exercise); err != nil {
+		return err
+	}
+	s.exercises[i] = cloneExercise(exercise)
+	return nil
+}
+
+func (s *Store) DeleteExercise(ctx context.Context, id primitive.ObjectID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.indexOf(id)
+	if i < 0 {
+		return store.ErrNotFound
+	}
+	s.exercises = append(s.exercises[:i], s.exercises[i+1:]...)
+	return nil
+}
+
+func (s *Store) AddExerciseMedia(ctx context.Context, exerciseID primitive.ObjectID, media models.Media) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.indexOf(exerciseID)
+	if i < 0 {
+		return store.ErrNotFound
+	}
+	s.exercises[i].Media = append(slices.Clone(s.exercises[i].Media), media)
+	return nil
+}
+
+func (s *Store) RemoveExerciseMedia(ctx context.Context, exerciseID, mediaID primitive.ObjectID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.indexOf(exerciseID)
+	if i < 0 {
+		return store.ErrNotFound
+	}
+	j := slices.IndexFunc(s.exercises[i].Media, func(m models.Media) bool { return m.ID == mediaID })
+	if j < 0 {
+		return store.ErrNotFound
+	}
+	s.exercises[i].Media = slices.Delete(slices.Clone(s.exercises[i].Media), j, j+1)
+	return nil
+}
+
+func (s *Store) nameTaken(exercise models.Exercise) bool {
+	for _, ex := range s.exercises {
+		if (ex.Name == exercise.Name || ex.Slug == exercise.Slug) && ex.ID != exercise.ID && sameOwner(ex.OwnerID, exercise.OwnerID) {
+			return true
+		}
+	}
+	return false
+}
+
+func sameOwner(a, b *primitive.ObjectID) bool {
+	return a == nil && b == nil || a != nil && b != nil && *a == *b
+}
+
+func (s *Store) indexOf(id primitive.ObjectID) int {
+	for i, ex := range s.exercises {
+		if ex.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *Store) GetExercises(ctx context.Context, filter store.ExerciseFilter) ([]models.Exercise, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var exercises []models.Exercise
+	for _, ex := range s.exercises {
+		if !filter.Matches(ex) {
+			continue
+		}
+		ex = cloneExercise(ex)
+		store.SortOptionRefs(&ex)
+		exercises = append(exercises, ex)
+	}
+	return exercises, nil
+}
+
+func (s *Store) ListExercises(ctx context.Context, filter store.ExerciseFilter, page store.PageRequest) (*store.ExercisePage, error) {
+	exercises, err := s.GetExercises(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if filter.Query != "" {
+		return store.PaginateSearch(exercises, filter.Query, page), nil
+	}
+	return store.Paginate(exercises, page), nil
+}
+
+func (s *Store) GetExercise(ctx context.Context, id primitive.ObjectID) (*models.Exercise, error) {
+	return s.findExercise(func(ex models.Exercise) bool { return ex.ID == id })
+}
+
+func (s *Store) GetExerciseBySlug(ctx context.Context, exerciseSlug string) (*models.Exercise, error) {
+	return s.findExercise(func(ex models.Exercise) bool { return ex.OwnerID == nil && ex.Slug == exerciseSlug })
+}
+
+func (s *Store) FindExerciseByName(ctx context.Context, name string) (*models.Exercise, error) {
+	return s.findExercise(func(ex models.Exercise) bool { return ex.OwnerID == nil && strings.EqualFold(ex.Name, name) })
+}
+
+func (s *Store) findExercise(match func(models.Exercise) bool) (*models.Exercise, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, ex := range s.exercises {
+		if match(ex) {
+			ex = cloneExercise(ex)
+			store.SortOptionRefs(&ex)
+			return &ex, nil
+		}
+	}
+	return nil, store.ErrNotFound
+}
+
+func (s *Store) GetUniqueExerciseNames(ctx context.Context) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	var names []string
+	for _, ex := range s.exercises {
+		if ex.OwnerID == nil && !seen[ex.Name] {
+			seen[ex.Name] = true
+			names = append(names, ex.Name)
+		}
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func cloneExercise(ex models.Exercise) models.Exercise {
+	ex.Aliases = slices.Clone(ex.Aliases)
+	ex.Equipment = slices.Clone(ex.Equipment)
+	ex.EquipmentIDs = slices.Clone(ex.EquipmentIDs)
+	ex.Muscles = slices.Clone(ex.Muscles)
+	ex.MuscleIDs = slices.Clone(ex.MuscleIDs)
+	ex.MuscleTargets = slices.Clone(ex.MuscleTargets)
+	ex.Instructions = slices.Clone(ex.Instructions)
+	ex.Cues = slices.Clone(ex.Cues)
+	ex.CommonMistakes = slices.Clone(ex.CommonMistakes)
+	ex.SafetyNotes = slices.Clone(ex.SafetyNotes)
+	ex.Media = slices.Clone(ex.Media)
+	ex.Translations = maps.Clone(ex.Translations)
+	return ex
+}
@@ -0,0 +1,187 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fitness-framework-api/internal/models"
+	"fitness-framework-api/internal/store"
+)
+
+// newExerciseStore returns an empty store with a few options, used only
+// through the ExerciseStore interface.
+func newExerciseStore(t *testing.T) store.ExerciseStore {
+	t.Helper()
+	var s store.ExerciseStore = NewStore()
+	ctx := context.Background()
+	for _, name := range []string{"Barbell", "Dumbbells"} {
+		if err := s.CreateOption(ctx, store.OptionEquipment, &models.Option{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range []string{"Chest", "Legs"} {
+		if err := s.CreateOption(ctx, store.OptionMuscle, &models.Option{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return s
+}
+
+func TestExerciseLifecycle(t *testing.T) {
+	ctx := context.Background()
+	s := newExerciseStore(t)
+
+	exercise := &models.Exercise{Name: "Barbell Squat", Equipment: []string{"barbell"}, Muscles: []string{"LEGS"}}
+	if err := s.CreateExercise(ctx, exercise); err != nil {
+		t.Fatal(err)
+	}
+	if exercise.ID.IsZero() || exercise.Slug != "barbell-squat" {
+		t.Fatalf("created exercise has ID %s and slug %q", exercise.ID.Hex(), exercise.Slug)
+	}
+
+	got, err := s.GetExercise(ctx, exercise.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got.Equipment, []string{"Barbell"}) || !slices.Equal(got.Muscles, []string{"Legs"}) || len(got.EquipmentIDs) != 1 || len(got.MuscleIDs) != 1 {
+		t.Errorf("stored equipment %v %v and muscles %v %v, want the registered names and IDs", got.Equipment, got.EquipmentIDs, got.Muscles, got.MuscleIDs)
+	}
+	got.Name = "Changed Outside The Store"
+	if again, _ := s.GetExercise(ctx, exercise.ID); again.Name != "Barbell Squat" {
+		t.Errorf("changing a returned exercise changed the store: %q", again.Name)
+	}
+	if bySlug, err := s.GetExerciseBySlug(ctx, "barbell-squat"); err != nil || bySlug.ID != exercise.ID {
+		t.Errorf("GetExerciseBySlug = %v, %v", bySlug, err)
+	}
+	if byName, err := s.FindExerciseByName(ctx, "Barbell Squat"); err != nil || byName.ID != exercise.ID {
+		t.Errorf("FindExerciseByName = %v, %v", byName, err)
+	}
+
+	updated := *exercise
+	updated.Name = "Back Squat"
+	updated.Equipment = []string{"Barbell", "Dumbbells"}
+	if err := s.UpdateExercise(ctx, updated); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := s.GetExercise(ctx, exercise.ID); got.Slug != "back-squat" || len(got.Equipment) != 2 {
+		t.Errorf("updated exercise has slug %q and equipment %v", got.Slug, got.Equipment)
+	}
+	if _, err := s.GetExerciseBySlug(ctx, "barbell-squat"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("old slug still finds the exercise: %v", err)
+	}
+
+	if err := s.DeleteExercise(ctx, exercise.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.GetExercise(ctx, exercise.ID); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("GetExercise after delete: %v, want ErrNotFound", err)
+	}
+	if err := s.DeleteExercise(ctx, exercise.ID); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("second DeleteExercise: %v, want ErrNotFound", err)
+	}
+	if err := s.UpdateExercise(ctx, updated); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("UpdateExercise after delete: %v, want ErrNotFound", err)
+	}
+}
+
+func TestExerciseErrors(t *testing.T) {
+	ctx := context.Background()
+	s := newExerciseStore(t)
+	owner := primitive.NewObjectID()
+
+	bench := &models.Exercise{Name: "Bench Press", Equipment: []string{"Barbell"}, Muscles: []string{"Chest"}}
+	if err := s.CreateExercise(ctx, bench); err != nil {
+		t.Fatal(err)
+	}
+	press := &models.Exercise{Name: "Dumbbell Press", Equipment: []string{"Dumbbells"}, Muscles: []string{"Chest"}}
+	if err := s.CreateExercise(ctx, press); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		run  func() error
+		want error
+	}{
+		{"duplicate name", func() error {
+			return s.CreateExercise(ctx, &models.Exercise{Name: "Bench Press", Equipment: []string{"Barbell"}, Muscles: []string{"Chest"}})
+		}, store.ErrDuplicate},
+		{"duplicate slug", func() error {
+			return s.CreateExercise(ctx, &models.Exercise{Name: "Bench-Press", Equipment: []string{"Barbell"}, Muscles: []string{"Chest"}})
+		}, store.ErrDuplicate},
+		{"same name for a custom exercise", func() error {
+			return s.CreateExercise(ctx, &models.Exercise{Name: "Bench Press", OwnerID: &owner, Equipment: []string{"Barbell"}, Muscles: []string{"Chest"}})
+		}, nil},
+		{"rename to a taken name", func() error {
+			renamed := *press
+			renamed.Name = "Bench Press"
+			return s.UpdateExercise(ctx, renamed)
+		}, store.ErrDuplicate},
+		{"unknown equipment", func() error {
+			return s.CreateExercise(ctx, &models.Exercise{Name: "Kettlebell Swing", Equipment: []string{"Kettlebell"}, Muscles: []string{"Legs"}})
+		}, store.ErrUnknownOption},
+		{"missing exercise", func() error {
+			_, err := s.GetExercise(ctx, primitive.NewObjectID())
+			return err
+		}, store.ErrNotFound},
+		{"missing slug", func() error {
+			_, err := s.GetExerciseBySlug(ctx, "missing")
+			return err
+		}, store.ErrNotFound},
+		{"media of a missing exercise", func() error {
+			return s.AddExerciseMedia(ctx, primitive.NewObjectID(), models.Media{ID: primitive.NewObjectID()})
+		}, store.ErrNotFound},
+		{"missing media", func() error {
+			return s.RemoveExerciseMedia(ctx, bench.ID, primitive.NewObjectID())
+		}, store.ErrNotFound},
+		{"duplicate option", func() error {
+			return s.CreateOption(ctx, store.OptionEquipment, &models.Option{Name: "Barbell"})
+		}, store.ErrDuplicate},
+		{"missing option", func() error {
+			return s.DeleteOption(ctx, store.OptionMuscle, primitive.NewObjectID())
+		}, store.ErrNotFound},
+	}
+	for _, tt := range tests {
+		if err := tt.run(); !errors.Is(err, tt.want) {
+			t.Errorf("%s: error %v, want %v", tt.name, err, tt.want)
+		}
+	}
+
+	if got, _ := s.GetExercise(ctx, press.ID); got.Name != "Dumbbell Press" {
+		t.Errorf("a failed update changed the exercise name to %q", got.Name)
+	}
+}
+
+func TestReplaceOptionInExercises(t *testing.T) {
+	ctx := context.Background()
+	s := newExerciseStore(t)
+	for _, ex := range []*models.Exercise{
+		{Name: "Bench Press", Equipment: []string{"Barbell"}, Muscles: []string{"Chest"}},
+		{Name: "Dumbbell Squat", Equipment: []string{"Dumbbells", "Barbell"}, Muscles: []string{"Legs"}},
+		{Name: "Goblet Squat", Equipment: []string{"Dumbbells"}, Muscles: []string{"Legs"}},
+	} {
+		if err := s.CreateExercise(ctx, ex); err != nil {
+			t.Fatal(err)
+		}
+	}
+	equipment, _ := s.GetOptions(ctx, store.OptionEquipment)
+	barbell, dumbbells := equipment[0], equipment[1]
+
+	if n, err := s.CountOptionUsage(ctx, store.OptionEquipment, barbell.ID); err != nil || n != 2 {
+		t.Errorf("CountOptionUsage = %d, %v, want 2", n, err)
+	}
+	if n, err := s.ReplaceOptionInExercises(ctx, store.OptionEquipment, barbell, &dumbbells); err != nil || n != 2 {
+		t.Errorf("ReplaceOptionInExercises = %d, %v, want 2", n, err)
+	}
+	if n, _ := s.CountOptionUsage(ctx, store.OptionEquipment, barbell.ID); n != 0 {
+		t.Errorf("barbell is still used by %d exercises", n)
+	}
+	squat, _ := s.FindExerciseByName(ctx, "Dumbbell Squat")
+	if !slices.Equal(squat.Equipment, []string{"Dumbbells"}) || !slices.Equal(squat.EquipmentIDs, []primitive.ObjectID{dumbbells.ID}) {
+		t.Errorf("merged equipment is %v %v, want only Dumbbells", squat.Equipment, squat.EquipmentIDs)
+	}
+}
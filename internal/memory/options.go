@@ -0,0 +1,157 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fitness-framework-api/internal/models"
+	"fitness-framework-api/internal/store"
+)
+
+func (s *Store) GetOptions(ctx context.Context, kind store.OptionKind) ([]models.Option, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	opts := slices.Clone(s.options[kind])
+	slices.SortFunc(opts, func(a, b models.Option) int { return strings.Compare(a.Name, b.Name) })
+	return opts, nil
+}
+
+func (s *Store) CreateOption(ctx context.Context, kind store.OptionKind, option *models.Option) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.optionIndexByName(kind, option.Name) >= 0 {
+		return store.ErrDuplicate
+	}
+	if option.ID.IsZero() {
+		option.ID = primitive.NewObjectID()
+	}
+	s.options[kind] = append(s.options[kind], *option)
+	return nil
+}
+
+func (s *Store) UpdateOption(ctx context.Context, kind store.OptionKind, option models.Option) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.optionIndex(kind, option.ID)
+	if i < 0 {
+		return store.ErrNotFound
+	}
+	if j := s.optionIndexByName(kind, option.Name); j >= 0 && j != i {
+		return store.ErrDuplicate
+	}
+	s.options[kind][i] = option
+	return nil
+}
+
+func (s *Store) DeleteOption(ctx context.Context, kind store.OptionKind, id primitive.ObjectID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.optionIndex(kind, id)
+	if i < 0 {
+		return store.ErrNotFound
+	}
+	s.options[kind] = slices.Delete(s.options[kind], i, i+1)
+	return nil
+}
+
+func (s *Store) CountOptionUsage(ctx context.Context, kind store.OptionKind, id primitive.ObjectID) (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var count int64
+	for _, ex := range s.exercises {
+		_, ids := optionFields(&ex, kind)
+		if slices.Contains(*ids, id) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *Store) ReplaceOptionInExercises(ctx context.Context, kind store.OptionKind, from models.Option, into *models.Option) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var modified int64
+	for i := range s.exercises {
+		names, ids := optionFields(&s.exercises[i], kind)
+		j := slices.Index(*ids, from.ID)
+		if j < 0 {
+			continue
+		}
+
+		newNames := slices.Clone(*names)
+		newIDs := slices.Clone(*ids)
+		if into != nil && into.ID == from.ID {
+			newNames[j] = into.Name
+		} else {
+			newNames = slices.Delete(newNames, j, j+1)
+			newIDs = slices.Delete(newIDs, j, j+1)
+			if into != nil && !slices.Contains(newIDs, into.ID) {
+				newNames = append(newNames, into.Name)
+				newIDs = append(newIDs, into.ID)
+			}
+		}
+		*names, *ids = newNames, newIDs
+		if kind == store.OptionMuscle {
+			s.exercises[i].MuscleTargets = store.ReplaceMuscleTarget(s.exercises[i].MuscleTargets, from, into)
+		}
+		modified++
+	}
+	return modified, nil
+}
+
+func (s *Store) GetUniqueEquipment(ctx context.Context) ([]string, error) {
+	return s.getOptionNames(ctx, store.OptionEquipment)
+}
+
+func (s *Store) GetUniqueMuscles(ctx context.Context) ([]string, error) {
+	return s.getOptionNames(ctx, store.OptionMuscle)
+}
+
+func (s *Store) getOptionNames(ctx context.Context, kind store.OptionKind) ([]string, error) {
+	opts, err := s.GetOptions(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(opts))
+	for _, option := range opts {
+		names = append(names, option.Name)
+	}
+	return names, nil
+}
+
+func (s *Store) resolveOptionRefs(exercise *models.Exercise) error {
+	var err error
+	if exercise.Equipment, exercise.EquipmentIDs, err = store.ResolveOptions(exercise.Equipment, s.options[store.OptionEquipment]); err != nil {
+		return err
+	}
+	if exercise.Muscles, exercise.MuscleIDs, err = store.ResolveOptions(exercise.Muscles, s.options[store.OptionMuscle]); err != nil {
+		return err
+	}
+	store.AlignMuscleTargets(exercise)
+	return nil
+}
+
+func (s *Store) optionIndex(kind store.OptionKind, id primitive.ObjectID) int {
+	return slices.IndexFunc(s.options[kind], func(o models.Option) bool { return o.ID == id })
+}
+
+func (s *Store) optionIndexByName(kind store.OptionKind, name string) int {
+	return slices.IndexFunc(s.options[kind], func(o models.Option) bool { return o.Name == name })
+}
+
+func optionFields(ex *models.Exercise, kind store.OptionKind) (*[]string, *[]primitive.ObjectID) {
+	if kind == store.OptionMuscle {
+		return &ex.Muscles, &ex.MuscleIDs
+	}
+	return &ex.Equipment, &ex.EquipmentIDs
+}
@@ -0,0 +1,118 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fitness-framework-api/internal/models"
+	"fitness-framework-api/internal/slug"
+	"fitness-framework-api/internal/store"
+)
+
+var _ store.ProfileStore = (*Store)(nil)
+
+func (s *Store) GetEquipmentProfiles(ctx context.Context, userID primitive.ObjectID) ([]models.EquipmentProfile, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var profiles []models.EquipmentProfile
+	for _, p := range s.profiles {
+		if p.UserID == userID {
+			p.EquipmentIDs = slices.Clone(p.EquipmentIDs)
+			profiles = append(profiles, p)
+		}
+	}
+	slices.SortFunc(profiles, func(a, b models.EquipmentProfile) int { return strings.Compare(a.Slug, b.Slug) })
+	return profiles, nil
+}
+
+func (s *Store) CreateEquipmentProfile(ctx context.Context, profile *models.EquipmentProfile) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profile.Slug = slug.Make(profile.Name)
+	if s.profileSlugTaken(*profile) {
+		return store.ErrDuplicate
+	}
+	if profile.ID.IsZero() {
+		profile.ID = primitive.NewObjectID()
+	}
+	p := *profile
+	p.Equipment = nil
+	p.EquipmentIDs = slices.Clone(p.EquipmentIDs)
+	s.profiles = append(s.profiles, p)
+	return nil
+}
+
+func (s *Store) UpdateEquipmentProfile(ctx context.Context, profile models.EquipmentProfile) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := slices.IndexFunc(s.profiles, func(p models.EquipmentProfile) bool {
+		return p.ID == profile.ID && p.UserID == profile.UserID
+	})
+	if i < 0 {
+		return store.ErrNotFound
+	}
+	profile.Slug = slug.Make(profile.Name)
+	if s.profileSlugTaken(profile) {
+		return store.ErrDuplicate
+	}
+	profile.Equipment = nil
+	profile.EquipmentIDs = slices.Clone(profile.EquipmentIDs)
+	s.profiles[i] = profile
+	return nil
+}
+
+func (s *Store) DeleteEquipmentProfile(ctx context.Context, userID, id primitive.ObjectID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := slices.IndexFunc(s.profiles, func(p models.EquipmentProfile) bool { return p.ID == id && p.UserID == userID })
+	if i < 0 {
+		return store.ErrNotFound
+	}
+	s.profiles = slices.Delete(s.profiles, i, i+1)
+	return nil
+}
+
+func (s *Store) MergeProfileEquipment(ctx context.Context, from, into primitive.ObjectID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, p := range s.profiles {
+		j := slices.Index(p.EquipmentIDs, from)
+		if j < 0 {
+			continue
+		}
+		ids := slices.Clone(p.EquipmentIDs)
+		if slices.Contains(ids, into) {
+			ids = slices.Delete(ids, j, j+1)
+		} else {
+			ids[j] = into
+		}
+		s.profiles[i].EquipmentIDs = ids
+	}
+	return nil
+}
+
+func (s *Store) RemoveProfileEquipment(ctx context.Context, id primitive.ObjectID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, p := range s.profiles {
+		if slices.Contains(p.EquipmentIDs, id) {
+			s.profiles[i].EquipmentIDs = slices.DeleteFunc(slices.Clone(p.EquipmentIDs), func(e primitive.ObjectID) bool { return e == id })
+		}
+	}
+	return nil
+}
+
+func (s *Store) profileSlugTaken(profile models.EquipmentProfile) bool {
+	return slices.ContainsFunc(s.profiles, func(p models.EquipmentProfile) bool {
+		return p.UserID == profile.UserID && p.Slug == profile.Slug && p.ID != profile.ID
+	})
+}
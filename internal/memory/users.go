@@ -0,0 +1,160 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fitness-framework-api/internal/models"
+	"fitness-framework-api/internal/store"
+)
+
+var _ store.UserStore = (*Store)(nil)
+
+func (s *Store) CreateUser(ctx context.Context, user *models.User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if slices.ContainsFunc(s.users, func(u models.User) bool { return u.Email == user.Email }) {
+		return store.ErrDuplicate
+	}
+	if user.ID.IsZero() {
+		user.ID = primitive.NewObjectID()
+	}
+	s.users = append(s.users, *user)
+	return nil
+}
+
+func (s *Store) GetUser(ctx context.Context, id primitive.ObjectID) (*models.User, error) {
+	return s.findUser(func(u models.User) bool { return u.ID == id })
+}
+
+func (s *Store) FindUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	return s.findUser(func(u models.User) bool { return u.Email == email })
+}
+
+func (s *Store) findUser(match func(models.User) bool) (*models.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := slices.IndexFunc(s.users, match)
+	if i < 0 {
+		return nil, store.ErrNotFound
+	}
+	user := s.users[i]
+	return &user, nil
+}
+
+func (s *Store) CreateSession(ctx context.Context, session *models.Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if session.ID.IsZero() {
+		session.ID = primitive.NewObjectID()
+	}
+	s.sessions = append(s.sessions, *session)
+	return nil
+}
+
+func (s *Store) FindSession(ctx context.Context, tokenHash string) (*models.Session, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := slices.IndexFunc(s.sessions, func(sess models.Session) bool { return sess.TokenHash == tokenHash })
+	if i < 0 {
+		return nil, store.ErrNotFound
+	}
+	session := s.sessions[i]
+	return &session, nil
+}
+
+func (s *Store) DeleteSession(ctx context.Context, id primitive.ObjectID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := slices.IndexFunc(s.sessions, func(sess models.Session) bool { return sess.ID == id })
+	if i < 0 {
+		return store.ErrNotFound
+	}
+	s.sessions = slices.Delete(s.sessions, i, i+1)
+	return nil
+}
+
+func (s *Store) DeleteUserSessions(ctx context.Context, userID primitive.ObjectID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions = slices.DeleteFunc(s.sessions, func(sess models.Session) bool { return sess.UserID == userID })
+	return nil
+}
+
+func (s *Store) ListUsers(ctx context.Context) ([]models.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return slices.Clone(s.users), nil
+}
+
+func (s *Store) SetUserRole(ctx context.Context, id primitive.ObjectID, role models.Role) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := slices.IndexFunc(s.users, func(u models.User) bool { return u.ID == id })
+	if i < 0 {
+		return store.ErrNotFound
+	}
+	s.users[i].Role = role
+	return nil
+}
+
+func (s *Store) CreateAPIKey(ctx context.Context, key *models.APIKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if key.ID.IsZero() {
+		key.ID = primitive.NewObjectID()
+	}
+	s.apiKeys = append(s.apiKeys, *key)
+	return nil
+}
+
+func (s *Store) ListAPIKeys(ctx context.Context, userID primitive.ObjectID) ([]models.APIKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var keys []models.APIKey
+	for _, key := range s.apiKeys {
+		if key.UserID == userID {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (s *Store) FindAPIKey(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := slices.IndexFunc(s.apiKeys, func(k models.APIKey) bool { return k.KeyHash == keyHash })
+	if i < 0 {
+		return nil, store.ErrNotFound
+	}
+	key := s.apiKeys[i]
+	return &key, nil
+}
+
+func (s *Store) RevokeAPIKey(ctx context.Context, userID, id primitive.ObjectID, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := slices.IndexFunc(s.apiKeys, func(k models.APIKey) bool {
+		return k.ID == id && k.UserID == userID && k.RevokedAt == nil
+	})
+	if i < 0 {
+		return store.ErrNotFound
+	}
+	s.apiKeys[i].RevokedAt = &at
+	return nil
+}
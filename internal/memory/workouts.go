@@ -0,0 +1,88 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fitness-framework-api/internal/models"
+	"fitness-framework-api/internal/store"
+)
+
+var _ store.WorkoutStore = (*Store)(nil)
+
+func (s *Store) ListWorkouts(ctx context.Context, filter store.WorkoutFilter) ([]models.Workout, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var workouts []models.Workout
+	for i := len(s.workouts) - 1; i >= 0; i-- {
+		if filter.Matches(s.workouts[i]) {
+			workouts = append(workouts, cloneWorkout(s.workouts[i]))
+		}
+	}
+	slices.SortStableFunc(workouts, func(a, b models.Workout) int { return b.StartedAt.Compare(a.StartedAt) })
+	if filter.Limit > 0 && len(workouts) > filter.Limit {
+		workouts = workouts[:filter.Limit]
+	}
+	return workouts, nil
+}
+
+func (s *Store) GetWorkout(ctx context.Context, userID, id primitive.ObjectID) (*models.Workout, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := slices.IndexFunc(s.workouts, func(w models.Workout) bool { return w.ID == id && w.UserID == userID })
+	if i < 0 {
+		return nil, store.ErrNotFound
+	}
+	w := cloneWorkout(s.workouts[i])
+	return &w, nil
+}
+
+func (s *Store) CreateWorkout(ctx context.Context, workout *models.Workout) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if workout.ID.IsZero() {
+		workout.ID = primitive.NewObjectID()
+	}
+	s.workouts = append(s.workouts, cloneWorkout(*workout))
+	return nil
+}
+
+func (s *Store) UpdateWorkout(ctx context.Context, workout models.Workout) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := slices.IndexFunc(s.workouts, func(w models.Workout) bool { return w.ID == workout.ID && w.UserID == workout.UserID })
+	if i < 0 {
+		return store.ErrNotFound
+	}
+	s.workouts[i] = cloneWorkout(workout)
+	return nil
+}
+
+func (s *Store) DeleteWorkout(ctx context.Context, userID, id primitive.ObjectID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := slices.IndexFunc(s.workouts, func(w models.Workout) bool { return w.ID == id && w.UserID == userID })
+	if i < 0 {
+		return store.ErrNotFound
+	}
+	s.workouts = slices.Delete(s.workouts, i, i+1)
+	return nil
+}
+
+// cloneWorkout copies the exercises and sets of w. The values the sets
+// point to are never changed in place and are shared.
+func cloneWorkout(w models.Workout) models.Workout {
+	w.Exercises = slices.Clone(w.Exercises)
+	for i := range w.Exercises {
+		w.Exercises[i].Name = ""
+		w.Exercises[i].Sets = slices.Clone(w.Exercises[i].Sets)
+	}
+	return w
+}
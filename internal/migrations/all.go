@@ -0,0 +1,417 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"fitness-framework-api/internal/models"
+	"fitness-framework-api/internal/mongodb"
+	"fitness-framework-api/internal/slug"
+)
+
+var All = []Migration{
+	{
+		Version:     1,
+		Description: "unique name indexes on exercises, equipment_options and muscles_options",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			for _, collection := range []string{mongodb.CollectionName, mongodb.EquipmentCollectionName, mongodb.MusclesCollectionName} {
+				if err := createIndex(ctx, db, collection, "name_unique", bson.D{{Key: "name", Value: 1}}, true, nil); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			for _, collection := range []string{mongodb.CollectionName, mongodb.EquipmentCollectionName, mongodb.MusclesCollectionName} {
+				if err := dropIndex(ctx, db, collection, "name_unique"); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version:     2,
+		Description: "case-insensitive indexes on exercise equipment and muscles",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			if err := createIndex(ctx, db, mongodb.CollectionName, "equipment_ci", bson.D{{Key: "equipment", Value: 1}}, false, mongodb.CaseInsensitive); err != nil {
+				return err
+			}
+			return createIndex(ctx, db, mongodb.CollectionName, "muscles_ci", bson.D{{Key: "muscles", Value: 1}}, false, mongodb.CaseInsensitive)
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			if err := dropIndex(ctx, db, mongodb.CollectionName, "equipment_ci"); err != nil {
+				return err
+			}
+			return dropIndex(ctx, db, mongodb.CollectionName, "muscles_ci")
+		},
+	},
+	{
+		Version:     3,
+		Description: "backfill exercise slugs and add a unique slug index",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			collection := db.Collection(mongodb.CollectionName)
+			cursor, err := collection.Find(ctx, bson.D{}, options.Find().SetProjection(bson.M{"name": 1}))
+			if err != nil {
+				return fmt.Errorf("failed to find exercises: %w", err)
+			}
+			defer cursor.Close(ctx)
+
+			for cursor.Next(ctx) {
+				var doc struct {
+					ID   primitive.ObjectID `bson:"_id"`
+					Name string             `bson:"name"`
+				}
+				if err := cursor.Decode(&doc); err != nil {
+					return fmt.Errorf("failed to decode exercise: %w", err)
+				}
+				if _, err := collection.UpdateByID(ctx, doc.ID, bson.M{"$set": bson.M{"slug": slug.Make(doc.Name)}}); err != nil {
+					return fmt.Errorf("failed to set slug for %q: %w", doc.Name, err)
+				}
+			}
+			if err := cursor.Err(); err != nil {
+				return fmt.Errorf("error during exercise iteration: %w", err)
+			}
+
+			return createIndex(ctx, db, mongodb.CollectionName, "slug_unique", bson.D{{Key: "slug", Value: 1}}, true, nil)
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			if err := dropIndex(ctx, db, mongodb.CollectionName, "slug_unique"); err != nil {
+				return err
+			}
+			if _, err := db.Collection(mongodb.CollectionName).UpdateMany(ctx, bson.D{}, bson.M{"$unset": bson.M{"slug": ""}}); err != nil {
+				return fmt.Errorf("failed to remove exercise slugs: %w", err)
+			}
+			return nil
+		},
+	},
+	{
+		Version:     4,
+		Description: "reference exercise equipment and muscles by option id",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			equipment, err := optionIDsByName(ctx, db, mongodb.EquipmentCollectionName)
+			if err != nil {
+				return err
+			}
+			muscles, err := optionIDsByName(ctx, db, mongodb.MusclesCollectionName)
+			if err != nil {
+				return err
+			}
+
+			collection := db.Collection(mongodb.CollectionName)
+			cursor, err := collection.Find(ctx, bson.D{})
+			if err != nil {
+				return fmt.Errorf("failed to find exercises: %w", err)
+			}
+			defer cursor.Close(ctx)
+
+			for cursor.Next(ctx) {
+				var doc struct {
+					ID        primitive.ObjectID `bson:"_id"`
+					Equipment []string           `bson:"equipment"`
+					Muscles   []string           `bson:"muscles"`
+				}
+				if err := cursor.Decode(&doc); err != nil {
+					return fmt.Errorf("failed to decode exercise: %w", err)
+				}
+
+				equipmentNames, equipmentIDs := resolveNames(doc.Equipment, equipment)
+				muscleNames, muscleIDs := resolveNames(doc.Muscles, muscles)
+				update := bson.M{"$set": bson.M{
+					"equipment":     equipmentNames,
+					"equipment_ids": equipmentIDs,
+					"muscles":       muscleNames,
+					"muscle_ids":    muscleIDs,
+				}}
+				if _, err := collection.UpdateByID(ctx, doc.ID, update); err != nil {
+					return fmt.Errorf("failed to set option ids for exercise %s: %w", doc.ID.Hex(), err)
+				}
+			}
+			if err := cursor.Err(); err != nil {
+				return fmt.Errorf("error during exercise iteration: %w", err)
+			}
+
+			if err := createIndex(ctx, db, mongodb.CollectionName, "equipment_ids", bson.D{{Key: "equipment_ids", Value: 1}}, false, nil); err != nil {
+				return err
+			}
+			return createIndex(ctx, db, mongodb.CollectionName, "muscle_ids", bson.D{{Key: "muscle_ids", Value: 1}}, false, nil)
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			if err := dropIndex(ctx, db, mongodb.CollectionName, "equipment_ids"); err != nil {
+				return err
+			}
+			if err := dropIndex(ctx, db, mongodb.CollectionName, "muscle_ids"); err != nil {
+				return err
+			}
+			if _, err := db.Collection(mongodb.CollectionName).UpdateMany(ctx, bson.D{}, bson.M{"$unset": bson.M{"equipment_ids": "", "muscle_ids": ""}}); err != nil {
+				return fmt.Errorf("failed to remove option ids from exercises: %w", err)
+			}
+			return nil
+		},
+	},
+	{
+		Version:     5,
+		Description: "backfill exercise muscle targets with the primary role",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			collection := db.Collection(mongodb.CollectionName)
+			cursor, err := collection.Find(ctx, bson.M{"muscle_targets": bson.M{"$exists": false}})
+			if err != nil {
+				return fmt.Errorf("failed to find exercises: %w", err)
+			}
+			defer cursor.Close(ctx)
+
+			for cursor.Next(ctx) {
+				var doc struct {
+					ID        primitive.ObjectID   `bson:"_id"`
+					Name      string               `bson:"name"`
+					Muscles   []string             `bson:"muscles"`
+					MuscleIDs []primitive.ObjectID `bson:"muscle_ids"`
+				}
+				if err := cursor.Decode(&doc); err != nil {
+					return fmt.Errorf("failed to decode exercise: %w", err)
+				}
+
+				// Every muscle listed before roles existed was a primary one.
+				targets := bson.A{}
+				for i, name := range doc.Muscles {
+					target := bson.M{"muscle": name, "muscle_id": primitive.NilObjectID, "role": "primary", "weight": 1.0}
+					if i < len(doc.MuscleIDs) {
+						target["muscle_id"] = doc.MuscleIDs[i]
+					}
+					targets = append(targets, target)
+				}
+				if _, err := collection.UpdateByID(ctx, doc.ID, bson.M{"$set": bson.M{"muscle_targets": targets}}); err != nil {
+					return fmt.Errorf("failed to set muscle targets for %q: %w", doc.Name, err)
+				}
+			}
+			if err := cursor.Err(); err != nil {
+				return fmt.Errorf("error during exercise iteration: %w", err)
+			}
+
+			keys := bson.D{{Key: "muscle_targets.muscle", Value: 1}, {Key: "muscle_targets.role", Value: 1}}
+			return createIndex(ctx, db, mongodb.CollectionName, "muscle_targets_ci", keys, false, mongodb.CaseInsensitive)
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			if err := dropIndex(ctx, db, mongodb.CollectionName, "muscle_targets_ci"); err != nil {
+				return err
+			}
+			if _, err := db.Collection(mongodb.CollectionName).UpdateMany(ctx, bson.D{}, bson.M{"$unset": bson.M{"muscle_targets": ""}}); err != nil {
+				return fmt.Errorf("failed to remove muscle targets from exercises: %w", err)
+			}
+			return nil
+		},
+	},
+	{
+		Version:     6,
+		Description: "indexes on exercise mechanics, movement pattern and difficulty",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			for _, field := range metadataIndexFields {
+				if err := createIndex(ctx, db, mongodb.CollectionName, field, bson.D{{Key: field, Value: 1}}, false, nil); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			for _, field := range metadataIndexFields {
+				if err := dropIndex(ctx, db, mongodb.CollectionName, field); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version:     7,
+		Description: "unique user email and session token indexes, and expiry of old sessions",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			if err := createIndex(ctx, db, mongodb.UsersCollectionName, "email_unique", bson.D{{Key: "email", Value: 1}}, true, nil); err != nil {
+				return err
+			}
+			if err := createIndex(ctx, db, mongodb.SessionsCollectionName, "token_hash_unique", bson.D{{Key: "token_hash", Value: 1}}, true, nil); err != nil {
+				return err
+			}
+			if err := createIndex(ctx, db, mongodb.SessionsCollectionName, "user_id", bson.D{{Key: "user_id", Value: 1}}, false, nil); err != nil {
+				return err
+			}
+			model := mongo.IndexModel{
+				Keys:    bson.D{{Key: "expires_at", Value: 1}},
+				Options: options.Index().SetName("expires_at_ttl").SetExpireAfterSeconds(0),
+			}
+			if _, err := db.Collection(mongodb.SessionsCollectionName).Indexes().CreateOne(ctx, model); err != nil {
+				return fmt.Errorf("failed to create index expires_at_ttl on %s: %w", mongodb.SessionsCollectionName, err)
+			}
+			return nil
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			for _, name := range []string{"token_hash_unique", "user_id", "expires_at_ttl"} {
+				if err := dropIndex(ctx, db, mongodb.SessionsCollectionName, name); err != nil {
+					return err
+				}
+			}
+			return dropIndex(ctx, db, mongodb.UsersCollectionName, "email_unique")
+		},
+	},
+	{
+		Version:     8,
+		Description: "default user role, API key indexes and an audit log index",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			filter := bson.M{"role": bson.M{"$exists": false}}
+			if _, err := db.Collection(mongodb.UsersCollectionName).UpdateMany(ctx, filter, bson.M{"$set": bson.M{"role": models.RoleUser}}); err != nil {
+				return fmt.Errorf("failed to backfill user roles: %w", err)
+			}
+			if err := createIndex(ctx, db, mongodb.APIKeysCollectionName, "key_hash_unique", bson.D{{Key: "key_hash", Value: 1}}, true, nil); err != nil {
+				return err
+			}
+			if err := createIndex(ctx, db, mongodb.APIKeysCollectionName, "user_id", bson.D{{Key: "user_id", Value: 1}}, false, nil); err != nil {
+				return err
+			}
+			return createIndex(ctx, db, mongodb.AuditCollectionName, "time", bson.D{{Key: "time", Value: -1}}, false, nil)
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			if err := dropIndex(ctx, db, mongodb.AuditCollectionName, "time"); err != nil {
+				return err
+			}
+			if err := dropIndex(ctx, db, mongodb.APIKeysCollectionName, "user_id"); err != nil {
+				return err
+			}
+			return dropIndex(ctx, db, mongodb.APIKeysCollectionName, "key_hash_unique")
+		},
+	},
+	{
+		Version:     9,
+		Description: "unique equipment profile slugs per user",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			return createIndex(ctx, db, mongodb.ProfilesCollectionName, "user_slug_unique", bson.D{{Key: "user_id", Value: 1}, {Key: "slug", Value: 1}}, true, nil)
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return dropIndex(ctx, db, mongodb.ProfilesCollectionName, "user_slug_unique")
+		},
+	},
+	{
+		Version:     10,
+		Description: "scope exercise name and slug uniqueness to the owner of custom exercises",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			for _, field := range []string{"name", "slug"} {
+				if err := dropIndex(ctx, db, mongodb.CollectionName, field+"_unique"); err != nil {
+					return err
+				}
+				if err := createIndex(ctx, db, mongodb.CollectionName, "owner_"+field+"_unique", bson.D{{Key: "owner_id", Value: 1}, {Key: field, Value: 1}}, true, nil); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			for _, field := range []string{"name", "slug"} {
+				if err := dropIndex(ctx, db, mongodb.CollectionName, "owner_"+field+"_unique"); err != nil {
+					return err
+				}
+				if err := createIndex(ctx, db, mongodb.CollectionName, field+"_unique", bson.D{{Key: field, Value: 1}}, true, nil); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version:     11,
+		Description: "index workouts by user and start time",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			return createIndex(ctx, db, mongodb.WorkoutsCollectionName, "user_started_at", bson.D{{Key: "user_id", Value: 1}, {Key: "started_at", Value: -1}}, false, nil)
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return dropIndex(ctx, db, mongodb.WorkoutsCollectionName, "user_started_at")
+		},
+	},
+	{
+		Version:     12,
+		Description: "case-insensitive unique names on exercises, equipment_options and muscles_options",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			return recreateNameIndexes(ctx, db, mongodb.CaseInsensitive)
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return recreateNameIndexes(ctx, db, nil)
+		},
+	},
+}
+
+var metadataIndexFields = []string{"mechanics", "movement_pattern", "difficulty"}
+
+// recreateNameIndexes replaces the unique name indexes created by versions
+// 1 and 10 with ones using collation, matching the lookups of the store.
+func recreateNameIndexes(ctx context.Context, db *mongo.Database, collation *options.Collation) error {
+	for _, collection := range []string{mongodb.EquipmentCollectionName, mongodb.MusclesCollectionName} {
+		if err := dropIndex(ctx, db, collection, "name_unique"); err != nil {
+			return err
+		}
+		if err := createIndex(ctx, db, collection, "name_unique", bson.D{{Key: "name", Value: 1}}, true, collation); err != nil {
+			return err
+		}
+	}
+	if err := dropIndex(ctx, db, mongodb.CollectionName, "owner_name_unique"); err != nil {
+		return err
+	}
+	return createIndex(ctx, db, mongodb.CollectionName, "owner_name_unique", bson.D{{Key: "owner_id", Value: 1}, {Key: "name", Value: 1}}, true, collation)
+}
+
+func optionIDsByName(ctx context.Context, db *mongo.Database, collection string) (map[string]bson.M, error) {
+	cursor, err := db.Collection(collection).Find(ctx, bson.D{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find %s: %w", collection, err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []bson.M
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", collection, err)
+	}
+
+	byName := make(map[string]bson.M, len(docs))
+	for _, doc := range docs {
+		if name, ok := doc["name"].(string); ok {
+			byName[strings.ToLower(name)] = doc
+		}
+	}
+	return byName, nil
+}
+
+func resolveNames(names []string, options map[string]bson.M) ([]string, []primitive.ObjectID) {
+	resolvedNames := []string{}
+	resolvedIDs := []primitive.ObjectID{}
+	for _, name := range names {
+		doc, ok := options[strings.ToLower(name)]
+		if !ok {
+			slog.Warn("Dropping unknown option from exercise", "option", name)
+			continue
+		}
+		resolvedNames = append(resolvedNames, doc["name"].(string))
+		resolvedIDs = append(resolvedIDs, doc["_id"].(primitive.ObjectID))
+	}
+	return resolvedNames, resolvedIDs
+}
+
+func createIndex(ctx context.Context, db *mongo.Database, collection, name string, keys bson.D, unique bool, collation *options.Collation) error {
+	model := mongo.IndexModel{
+		Keys:    keys,
+		Options: options.Index().SetName(name).SetUnique(unique).SetCollation(collation),
+	}
+	if _, err := db.Collection(collection).Indexes().CreateOne(ctx, model); err != nil {
+		return fmt.Errorf("failed to create index %s on %s: %w", name, collection, err)
+	}
+	return nil
+}
+
+func dropIndex(ctx context.Context, db *mongo.Database, collection, name string) error {
+	if _, err := db.Collection(collection).Indexes().DropOne(ctx, name); err != nil {
+		return fmt.Errorf("failed to drop index %s on %s: %w", name, collection, err)
+	}
+	return nil
+}
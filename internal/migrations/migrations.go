@@ -0,0 +1,144 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"fitness-framework-api/internal/models"
+)
+
+const (
+	CollectionName = "migrations"
+)
+
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, db *mongo.Database) error
+	Down        func(ctx context.Context, db *mongo.Database) error
+}
+
+type Migrator struct {
+	DB         *mongo.Database
+	Migrations []Migration
+}
+
+func NewMigrator(db *mongo.Database) *Migrator {
+	sorted := append([]Migration(nil), All...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+	return &Migrator{DB: db, Migrations: sorted}
+}
+
+func (m *Migrator) applied(ctx context.Context) ([]models.AppliedMigration, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	cursor, err := m.DB.Collection(CollectionName).Find(ctx, bson.D{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find applied migrations: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var applied []models.AppliedMigration
+	if err := cursor.All(ctx, &applied); err != nil {
+		return nil, fmt.Errorf("failed to decode applied migrations: %w", err)
+	}
+	return applied, nil
+}
+
+func (m *Migrator) Status(ctx context.Context) (*models.MigrationStatus, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	done := make(map[int]bool, len(applied))
+	status := &models.MigrationStatus{Applied: applied, Pending: []int{}}
+	for _, a := range applied {
+		done[a.Version] = true
+		if a.Version > status.CurrentVersion {
+			status.CurrentVersion = a.Version
+		}
+	}
+	for _, mig := range m.Migrations {
+		status.LatestVersion = mig.Version
+		if !done[mig.Version] {
+			status.Pending = append(status.Pending, mig.Version)
+		}
+	}
+	return status, nil
+}
+
+func (m *Migrator) Up(ctx context.Context, target int) ([]int, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	done := make(map[int]bool, len(applied))
+	for _, a := range applied {
+		done[a.Version] = true
+	}
+
+	var ran []int
+	for _, mig := range m.Migrations {
+		if target > 0 && mig.Version > target {
+			break
+		}
+		if done[mig.Version] {
+			continue
+		}
+
+		slog.Info("Applying migration", "version", mig.Version, "description", mig.Description)
+		if err := mig.Up(ctx, m.DB); err != nil {
+			return ran, fmt.Errorf("migration %d (%s) failed: %w", mig.Version, mig.Description, err)
+		}
+
+		record := models.AppliedMigration{Version: mig.Version, Description: mig.Description, AppliedAt: time.Now().UTC()}
+		if _, err := m.DB.Collection(CollectionName).InsertOne(ctx, record); err != nil {
+			return ran, fmt.Errorf("failed to record migration %d: %w", mig.Version, err)
+		}
+		ran = append(ran, mig.Version)
+	}
+
+	return ran, nil
+}
+
+func (m *Migrator) Down(ctx context.Context, steps int) ([]int, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]Migration, len(m.Migrations))
+	for _, mig := range m.Migrations {
+		byVersion[mig.Version] = mig
+	}
+
+	var reverted []int
+	for i := len(applied) - 1; i >= 0 && len(reverted) < steps; i-- {
+		mig, ok := byVersion[applied[i].Version]
+		if !ok {
+			return reverted, fmt.Errorf("applied migration %d is not known to this build", applied[i].Version)
+		}
+
+		slog.Info("Reverting migration", "version", mig.Version, "description", mig.Description)
+		if err := mig.Down(ctx, m.DB); err != nil {
+			return reverted, fmt.Errorf("reverting migration %d (%s) failed: %w", mig.Version, mig.Description, err)
+		}
+
+		if _, err := m.DB.Collection(CollectionName).DeleteOne(ctx, bson.M{"_id": mig.Version}); err != nil {
+			return reverted, fmt.Errorf("failed to remove migration record %d: %w", mig.Version, err)
+		}
+		reverted = append(reverted, mig.Version)
+	}
+
+	return reverted, nil
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AuditEvent records who changed the shared catalog.
+type AuditEvent struct {
+	ID         primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
+	Time       time.Time           `json:"time" bson:"time"`
+	UserID     primitive.ObjectID  `json:"userId" bson:"user_id"`
+	APIKeyID   *primitive.ObjectID `json:"apiKeyId,omitempty" bson:"api_key_id,omitempty"`
+	Action     string              `json:"action" bson:"action"`
+	Target     string              `json:"target" bson:"target"`
+	TargetID   string              `json:"targetId" bson:"target_id"`
+	TargetName string              `json:"targetName,omitempty" bson:"target_name,omitempty"`
+}
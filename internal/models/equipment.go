@@ -1,6 +1,3 @@
 package models
 
-type Equipment struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
-}
+type Equipment = Option
@@ -3,14 +3,66 @@ package models
 import "go.mongodb.org/mongo-driver/bson/primitive"
 
 type Exercise struct {
-	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
-	Name      string             `json:"name" bson:"name"`
-	Equipment []string           `json:"equipment" bson:"equipment"`
-	Muscles   []string           `json:"muscles" bson:"muscles"`
+	ID            primitive.ObjectID   `json:"id" bson:"_id,omitempty"`
+	Name          string               `json:"name" bson:"name"`
+	Slug          string               `json:"slug" bson:"slug"`
+	Aliases       []string             `json:"aliases,omitempty" bson:"aliases,omitempty"`
+	VariationOf   *primitive.ObjectID  `json:"variationOf,omitempty" bson:"variation_of,omitempty"`
+	OwnerID       *primitive.ObjectID  `json:"ownerId,omitempty" bson:"owner_id,omitempty"`
+	Equipment     []string             `json:"equipment" bson:"equipment"`
+	EquipmentIDs  []primitive.ObjectID `json:"equipmentIds" bson:"equipment_ids"`
+	Muscles       []string             `json:"muscles" bson:"muscles"`
+	MuscleIDs     []primitive.ObjectID `json:"muscleIds" bson:"muscle_ids"`
+	MuscleTargets []MuscleTarget       `json:"muscleTargets" bson:"muscle_targets"`
+
+	Mechanics          string `json:"mechanics,omitempty" bson:"mechanics,omitempty"`
+	Force              string `json:"force,omitempty" bson:"force,omitempty"`
+	MovementPattern    string `json:"movementPattern,omitempty" bson:"movement_pattern,omitempty"`
+	Laterality         string `json:"laterality,omitempty" bson:"laterality,omitempty"`
+	Difficulty         string `json:"difficulty,omitempty" bson:"difficulty,omitempty"`
+	BodyweightLoadable *bool  `json:"bodyweightLoadable,omitempty" bson:"bodyweight_loadable,omitempty"`
+
+	Description    string   `json:"description,omitempty" bson:"description,omitempty"`
+	Instructions   []string `json:"instructions,omitempty" bson:"instructions,omitempty"`
+	Cues           []string `json:"cues,omitempty" bson:"cues,omitempty"`
+	CommonMistakes []string `json:"commonMistakes,omitempty" bson:"common_mistakes,omitempty"`
+	SafetyNotes    []string `json:"safetyNotes,omitempty" bson:"safety_notes,omitempty"`
+
+	Media []Media `json:"media,omitempty" bson:"media,omitempty"`
+
+	// Translations holds the localized text per locale. Responses show it
+	// in place of the English text instead of returning it as is.
+	Translations map[string]ExerciseTranslation `json:"-" bson:"translations,omitempty"`
+
+	Seed *SeedRef `json:"-" bson:"seed,omitempty"`
+}
+
+type MuscleTarget struct {
+	Muscle   string             `json:"muscle" bson:"muscle"`
+	MuscleID primitive.ObjectID `json:"muscleId" bson:"muscle_id"`
+	Role     string             `json:"role" bson:"role"`
+	Weight   float64            `json:"weight" bson:"weight"`
 }
 
 type RawExercise struct {
-	Name      string   `json:"name"`
-	Equipment []string `json:"equipment"`
-	Muscles   []string `json:"muscles"`
+	Name              string   `json:"name" yaml:"name"`
+	Aliases           []string `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+	VariationOf       string   `json:"variationOf,omitempty" yaml:"variationOf,omitempty"`
+	Equipment         []string `json:"equipment" yaml:"equipment"`
+	Muscles           []string `json:"muscles" yaml:"muscles"`
+	SecondaryMuscles  []string `json:"secondaryMuscles,omitempty" yaml:"secondaryMuscles,omitempty"`
+	StabilizerMuscles []string `json:"stabilizerMuscles,omitempty" yaml:"stabilizerMuscles,omitempty"`
+
+	Mechanics          string `json:"mechanics,omitempty" yaml:"mechanics,omitempty"`
+	Force              string `json:"force,omitempty" yaml:"force,omitempty"`
+	MovementPattern    string `json:"movementPattern,omitempty" yaml:"movementPattern,omitempty"`
+	Laterality         string `json:"laterality,omitempty" yaml:"laterality,omitempty"`
+	Difficulty         string `json:"difficulty,omitempty" yaml:"difficulty,omitempty"`
+	BodyweightLoadable *bool  `json:"bodyweightLoadable,omitempty" yaml:"bodyweightLoadable,omitempty"`
+
+	Description    string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Instructions   []string `json:"instructions,omitempty" yaml:"instructions,omitempty"`
+	Cues           []string `json:"cues,omitempty" yaml:"cues,omitempty"`
+	CommonMistakes []string `json:"commonMistakes,omitempty" yaml:"commonMistakes,omitempty"`
+	SafetyNotes    []string `json:"safetyNotes,omitempty" yaml:"safetyNotes,omitempty"`
 }
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Media struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id"`
+	Kind        string             `json:"kind" bson:"kind"`
+	ContentType string             `json:"contentType" bson:"content_type"`
+	Size        int64              `json:"size" bson:"size"`
+	Width       int                `json:"width,omitempty" bson:"width,omitempty"`
+	Height      int                `json:"height,omitempty" bson:"height,omitempty"`
+	Caption     string             `json:"caption,omitempty" bson:"caption,omitempty"`
+	Key         string             `json:"-" bson:"key"`
+	URL         string             `json:"url" bson:"-"`
+	Thumbnail   *Thumbnail         `json:"thumbnail,omitempty" bson:"thumbnail,omitempty"`
+	CreatedAt   time.Time          `json:"createdAt" bson:"created_at"`
+}
+
+type Thumbnail struct {
+	ContentType string `json:"contentType" bson:"content_type"`
+	Width       int    `json:"width,omitempty" bson:"width,omitempty"`
+	Height      int    `json:"height,omitempty" bson:"height,omitempty"`
+	Key         string `json:"-" bson:"key"`
+	URL         string `json:"url" bson:"-"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+type AppliedMigration struct {
+	Version     int       `json:"version" bson:"_id"`
+	Description string    `json:"description" bson:"description"`
+	AppliedAt   time.Time `json:"appliedAt" bson:"applied_at"`
+}
+
+type MigrationStatus struct {
+	CurrentVersion int                `json:"currentVersion"`
+	LatestVersion  int                `json:"latestVersion"`
+	Pending        []int              `json:"pending"`
+	Applied        []AppliedMigration `json:"applied"`
+}
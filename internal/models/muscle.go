@@ -1,6 +1,3 @@
 package models
 
-type Muscle struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
-}
+type Muscle = Option
@@ -0,0 +1,14 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+type Option struct {
+	ID          primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
+	Name        string              `json:"name" bson:"name"`
+	Description string              `json:"description,omitempty" bson:"description,omitempty"`
+	Category    string              `json:"category,omitempty" bson:"category,omitempty"`
+	ParentID    *primitive.ObjectID `json:"parentId,omitempty" bson:"parent_id,omitempty"`
+
+	Translations map[string]OptionTranslation `json:"-" bson:"translations,omitempty"`
+	Seed         *SeedRef                     `json:"-" bson:"seed,omitempty"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// EquipmentProfile is a named set of equipment a user has access to, such
+// as their home gym. It stores option IDs so that renamed options stay in
+// the profile; Equipment holds their current names.
+type EquipmentProfile struct {
+	ID           primitive.ObjectID   `json:"id" bson:"_id,omitempty"`
+	UserID       primitive.ObjectID   `json:"-" bson:"user_id"`
+	Name         string               `json:"name" bson:"name"`
+	Slug         string               `json:"slug" bson:"slug"`
+	Equipment    []string             `json:"equipment" bson:"-"`
+	EquipmentIDs []primitive.ObjectID `json:"equipmentIds" bson:"equipment_ids"`
+	CreatedAt    time.Time            `json:"createdAt" bson:"created_at"`
+	UpdatedAt    time.Time            `json:"updatedAt" bson:"updated_at"`
+}
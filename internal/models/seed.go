@@ -0,0 +1,10 @@
+package models
+
+// SeedRef marks a document the catalog sync created from the seed. Key
+// names the seed entry and Hash the values the sync last wrote. A document
+// that no longer matches Hash was changed through the API, and the sync
+// leaves it alone.
+type SeedRef struct {
+	Key  string `bson:"key"`
+	Hash string `bson:"hash"`
+}
@@ -0,0 +1,15 @@
+package models
+
+type ExerciseTranslation struct {
+	Name           string   `json:"name,omitempty" yaml:"name,omitempty" bson:"name,omitempty"`
+	Description    string   `json:"description,omitempty" yaml:"description,omitempty" bson:"description,omitempty"`
+	Instructions   []string `json:"instructions,omitempty" yaml:"instructions,omitempty" bson:"instructions,omitempty"`
+	Cues           []string `json:"cues,omitempty" yaml:"cues,omitempty" bson:"cues,omitempty"`
+	CommonMistakes []string `json:"commonMistakes,omitempty" yaml:"commonMistakes,omitempty" bson:"common_mistakes,omitempty"`
+	SafetyNotes    []string `json:"safetyNotes,omitempty" yaml:"safetyNotes,omitempty" bson:"safety_notes,omitempty"`
+}
+
+type OptionTranslation struct {
+	Name        string `json:"name,omitempty" yaml:"name,omitempty" bson:"name,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty" bson:"description,omitempty"`
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Role string
+
+const (
+	RoleUser  Role = "user"
+	RoleCoach Role = "coach"
+	RoleAdmin Role = "admin"
+)
+
+// Roles lists the roles from least to most privileged.
+var Roles = []Role{RoleUser, RoleCoach, RoleAdmin}
+
+func (r Role) Valid() bool {
+	return slices.Contains(Roles, r)
+}
+
+// Includes reports whether r grants everything other does.
+func (r Role) Includes(other Role) bool {
+	return slices.Index(Roles, r) >= slices.Index(Roles, other)
+}
+
+type User struct {
+	ID           primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Email        string             `json:"email" bson:"email"`
+	Name         string             `json:"name,omitempty" bson:"name,omitempty"`
+	PasswordHash string             `json:"-" bson:"password_hash"`
+	Role         Role               `json:"role" bson:"role"`
+	CreatedAt    time.Time          `json:"createdAt" bson:"created_at"`
+}
+
+// Session is a login that can be extended with its refresh token. Only a
+// hash of the token is stored.
+type Session struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	UserID    primitive.ObjectID `bson:"user_id"`
+	TokenHash string             `bson:"token_hash"`
+	CreatedAt time.Time          `bson:"created_at"`
+	ExpiresAt time.Time          `bson:"expires_at"`
+}
+
+// APIKey authenticates scripts and integrations as its owner, with the
+// owner's role. Only a hash of the key is stored.
+type APIKey struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	UserID    primitive.ObjectID `json:"userId" bson:"user_id"`
+	Name      string             `json:"name" bson:"name"`
+	Prefix    string             `json:"prefix" bson:"prefix"`
+	KeyHash   string             `json:"-" bson:"key_hash"`
+	CreatedAt time.Time          `json:"createdAt" bson:"created_at"`
+	RevokedAt *time.Time         `json:"revokedAt,omitempty" bson:"revoked_at,omitempty"`
+}
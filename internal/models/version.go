@@ -1,6 +1,7 @@
 package models
 
 type ApiInfo struct {
-	Version   string `json:"version"`
-	BuildType string `json:"buildType"`
+	Version    string           `json:"version"`
+	BuildType  string           `json:"buildType"`
+	Migrations *MigrationStatus `json:"migrations,omitempty"`
 }
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Workout is a training session of a user. A workout without EndedAt is
+// still in progress.
+type Workout struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	UserID    primitive.ObjectID `json:"-" bson:"user_id"`
+	StartedAt time.Time          `json:"startedAt" bson:"started_at"`
+	EndedAt   *time.Time         `json:"endedAt,omitempty" bson:"ended_at,omitempty"`
+	Notes     string             `json:"notes,omitempty" bson:"notes,omitempty"`
+	Exercises []WorkoutExercise  `json:"exercises" bson:"exercises"`
+	CreatedAt time.Time          `json:"createdAt" bson:"created_at"`
+	UpdatedAt time.Time          `json:"updatedAt" bson:"updated_at"`
+}
+
+// WorkoutExercise is an exercise done during a workout, in the order they
+// were done. Name holds the current name of the exercise.
+type WorkoutExercise struct {
+	ExerciseID primitive.ObjectID `json:"exerciseId" bson:"exercise_id"`
+	Name       string             `json:"name,omitempty" bson:"-"`
+	Notes      string             `json:"notes,omitempty" bson:"notes,omitempty"`
+	Sets       []WorkoutSet       `json:"sets" bson:"sets"`
+}
+
+// WorkoutSet is a single set of an exercise. Weight is given in Unit; RPE
+// and RIR rate how hard the set was.
+type WorkoutSet struct {
+	Type        string   `json:"type" bson:"type"`
+	Reps        *int     `json:"reps,omitempty" bson:"reps,omitempty"`
+	Weight      *float64 `json:"weight,omitempty" bson:"weight,omitempty"`
+	Unit        string   `json:"unit,omitempty" bson:"unit,omitempty"`
+	RPE         *float64 `json:"rpe,omitempty" bson:"rpe,omitempty"`
+	RIR         *int     `json:"rir,omitempty" bson:"rir,omitempty"`
+	RestSeconds *int     `json:"restSeconds,omitempty" bson:"rest_seconds,omitempty"`
+}
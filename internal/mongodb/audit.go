@@ -0,0 +1,58 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"fitness-framework-api/internal/models"
+	"fitness-framework-api/internal/store"
+)
+
+var _ store.AuditStore = (*Store)(nil)
+
+func (s *Store) RecordAudit(ctx context.Context, event *models.AuditEvent) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if event.ID.IsZero() {
+		event.ID = primitive.NewObjectID()
+	}
+	if _, err := s.DB.Collection(AuditCollectionName).InsertOne(ctx, event); err != nil {
+		return fmt.Errorf("failed to insert audit event: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) ListAudit(ctx context.Context, filter store.AuditFilter) ([]models.AuditEvent, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := bson.M{}
+	if filter.UserID != nil {
+		query["user_id"] = *filter.UserID
+	}
+	if filter.Target != "" {
+		query["target"] = filter.Target
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "time", Value: -1}, {Key: "_id", Value: -1}})
+	if filter.Limit > 0 {
+		opts.SetLimit(int64(filter.Limit))
+	}
+
+	cursor, err := s.DB.Collection(AuditCollectionName).Find(ctx, query, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find audit events: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var events []models.AuditEvent
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, fmt.Errorf("failed to decode audit events: %w", err)
+	}
+	return events, nil
+}
@@ -0,0 +1,129 @@
+package mongodb
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"fitness-framework-api/internal/store"
+)
+
+var CaseInsensitive = &options.Collation{Locale: "en", Strength: 2}
+
+func exerciseQuery(filter store.ExerciseFilter) bson.D {
+	conds := setConds(filter.Equipment, func(cond bson.M) bson.M {
+		return bson.M{"equipment": bson.M{"$elemMatch": cond}}
+	})
+	if len(filter.MuscleRoles) > 0 {
+		conds = append(conds, setConds(filter.Muscles, func(cond bson.M) bson.M {
+			return bson.M{"muscle_targets": bson.M{"$elemMatch": bson.M{"muscle": cond, "role": bson.M{"$in": filter.MuscleRoles}}}}
+		})...)
+	} else {
+		conds = append(conds, setConds(filter.Muscles, func(cond bson.M) bson.M {
+			return bson.M{"muscles": bson.M{"$elemMatch": cond}}
+		})...)
+	}
+
+	for _, attr := range []struct {
+		field  string
+		values []string
+	}{
+		{"mechanics", filter.Mechanics},
+		{"force", filter.Force},
+		{"movement_pattern", filter.MovementPatterns},
+		{"laterality", filter.Laterality},
+		{"difficulty", filter.Difficulty},
+	} {
+		if len(attr.values) > 0 {
+			conds = append(conds, bson.M{attr.field: bson.M{"$in": attr.values}})
+		}
+	}
+	if filter.BodyweightLoadable != nil {
+		conds = append(conds, bson.M{"bodyweight_loadable": *filter.BodyweightLoadable})
+	}
+	if filter.IDs != nil {
+		conds = append(conds, bson.M{"_id": bson.M{"$in": filter.IDs}})
+	}
+	if cond := ownerCond(filter); cond != nil {
+		conds = append(conds, cond)
+	}
+
+	if len(conds) == 0 {
+		return bson.D{}
+	}
+	return bson.D{{Key: "$and", Value: conds}}
+}
+
+// ownerCond mirrors the owner fields of store.ExerciseFilter.Matches. A
+// missing owner_id matches nil.
+func ownerCond(filter store.ExerciseFilter) bson.M {
+	custom := filter.Custom != nil && *filter.Custom
+	switch {
+	case filter.Custom != nil && !custom:
+		return bson.M{"owner_id": nil}
+	case filter.AllOwners && custom:
+		return bson.M{"owner_id": bson.M{"$ne": nil}}
+	case filter.AllOwners:
+		return nil
+	case filter.Owner != nil && custom:
+		return bson.M{"owner_id": *filter.Owner}
+	case filter.Owner != nil:
+		return bson.M{"owner_id": bson.M{"$in": bson.A{nil, *filter.Owner}}}
+	case custom:
+		return bson.M{"owner_id": bson.M{"$in": bson.A{}}}
+	default:
+		return bson.M{"owner_id": nil}
+	}
+}
+
+// setConds mirrors store.SetFilter.Matches. has builds a condition that is true
+// when at least one element of the exercise's list matches cond.
+func setConds(filter store.SetFilter, has func(cond bson.M) bson.M) bson.A {
+	none := func(cond bson.M) bson.M {
+		return bson.M{"$nor": bson.A{has(cond)}}
+	}
+
+	var conds bson.A
+	if filter.Mode == store.MatchSubset || (filter.Mode == store.MatchExact && len(filter.Values) > 0) {
+		values := filter.ExpandAll(filter.Values)
+		if values == nil {
+			values = []string{}
+		}
+		conds = append(conds, none(bson.M{"$nin": values}))
+	}
+	if len(filter.Values) > 0 {
+		switch filter.Mode {
+		case store.MatchAll, store.MatchExact:
+			for _, value := range store.UniqueFold(filter.Values) {
+				conds = append(conds, has(bson.M{"$in": filter.Expand(value)}))
+			}
+		case store.MatchAny:
+			conds = append(conds, has(bson.M{"$in": filter.ExpandAll(filter.Values)}))
+		}
+	}
+	if len(filter.Exclude) > 0 {
+		conds = append(conds, none(bson.M{"$in": filter.ExpandAll(filter.Exclude)}))
+	}
+	return conds
+}
+
+var sortKeys = map[string]string{
+	"name": "name",
+	"id":   "_id",
+}
+
+func keysetQuery(key string, c *store.Cursor, op string) bson.M {
+	if key == "_id" {
+		return bson.M{"_id": bson.M{op: c.ID}}
+	}
+	return bson.M{"$or": bson.A{
+		bson.M{key: bson.M{op: c.Value}},
+		bson.M{key: c.Value, "_id": bson.M{op: c.ID}},
+	}}
+}
+
+func sortSpec(key string, direction int) bson.D {
+	if key == "_id" {
+		return bson.D{{Key: "_id", Value: direction}}
+	}
+	return bson.D{{Key: key, Value: direction}, {Key: "_id", Value: direction}}
+}
@@ -0,0 +1,276 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"fitness-framework-api/internal/memory"
+	"fitness-framework-api/internal/models"
+	"fitness-framework-api/internal/store"
+)
+
+// TestExerciseQueryMatchesInProcessFilter runs each filter against a
+// MongoDB server, ExerciseFilter.Matches and the memory store, which must
+// all select the same exercises. It is skipped when no server is reachable
+// at MongoURI.
+func TestExerciseQueryMatchesInProcessFilter(t *testing.T) {
+	ctx := context.Background()
+	s, exercises := filterFixture(t)
+	db := testDatabase(t, s)
+
+	muscles, err := s.GetOptions(ctx, store.OptionMuscle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expansions := store.Descendants(muscles)
+
+	tests := []struct {
+		name   string
+		filter store.ExerciseFilter
+		want   []string
+	}{
+		{
+			name:   "no filter",
+			filter: store.ExerciseFilter{},
+			want:   []string{"Barbell Bench Press", "Dumbbell Bench Press", "Dumbbell Row", "Pullup", "Push-Up"},
+		},
+		{
+			name:   "any",
+			filter: store.ExerciseFilter{Equipment: store.SetFilter{Values: []string{"Barbell", "Pullup Bar"}, Mode: store.MatchAny}},
+			want:   []string{"Barbell Bench Press", "Pullup"},
+		},
+		{
+			name:   "any ignores case",
+			filter: store.ExerciseFilter{Equipment: store.SetFilter{Values: []string{"DUMBBELLS"}, Mode: store.MatchAny}},
+			want:   []string{"Dumbbell Bench Press", "Dumbbell Row"},
+		},
+		{
+			name:   "any with empty set",
+			filter: store.ExerciseFilter{Equipment: store.SetFilter{Values: []string{}, Mode: store.MatchAny}},
+			want:   []string{"Barbell Bench Press", "Dumbbell Bench Press", "Dumbbell Row", "Pullup", "Push-Up"},
+		},
+		{
+			name:   "all",
+			filter: store.ExerciseFilter{Equipment: store.SetFilter{Values: []string{"dumbbells", "Flat Bench"}, Mode: store.MatchAll}},
+			want:   []string{"Dumbbell Bench Press"},
+		},
+		{
+			name:   "all with repeated value",
+			filter: store.ExerciseFilter{Equipment: store.SetFilter{Values: []string{"Dumbbells", "dumbbells"}, Mode: store.MatchAll}},
+			want:   []string{"Dumbbell Bench Press", "Dumbbell Row"},
+		},
+		{
+			name:   "all with empty set",
+			filter: store.ExerciseFilter{Equipment: store.SetFilter{Mode: store.MatchAll}},
+			want:   []string{"Barbell Bench Press", "Dumbbell Bench Press", "Dumbbell Row", "Pullup", "Push-Up"},
+		},
+		{
+			name:   "exact",
+			filter: store.ExerciseFilter{Equipment: store.SetFilter{Values: []string{"flat bench", "BARBELL"}, Mode: store.MatchExact}},
+			want:   []string{"Barbell Bench Press"},
+		},
+		{
+			name:   "exact needs every item",
+			filter: store.ExerciseFilter{Equipment: store.SetFilter{Values: []string{"Flat Bench"}, Mode: store.MatchExact}},
+			want:   nil,
+		},
+		{
+			name:   "exact with empty set",
+			filter: store.ExerciseFilter{Equipment: store.SetFilter{Mode: store.MatchExact}},
+			want:   []string{"Barbell Bench Press", "Dumbbell Bench Press", "Dumbbell Row", "Pullup", "Push-Up"},
+		},
+		{
+			name:   "subset",
+			filter: store.ExerciseFilter{Equipment: store.SetFilter{Values: []string{"Dumbbells", "flat bench", "None"}, Mode: store.MatchSubset}},
+			want:   []string{"Dumbbell Bench Press", "Dumbbell Row", "Push-Up"},
+		},
+		{
+			name:   "subset with empty set",
+			filter: store.ExerciseFilter{Equipment: store.SetFilter{Mode: store.MatchSubset}},
+			want:   nil,
+		},
+		{
+			name:   "exclude",
+			filter: store.ExerciseFilter{Equipment: store.SetFilter{Mode: store.MatchAny, Exclude: []string{"flat BENCH"}}},
+			want:   []string{"Dumbbell Row", "Pullup", "Push-Up"},
+		},
+		{
+			name:   "muscle group matches its muscles",
+			filter: store.ExerciseFilter{Muscles: store.SetFilter{Values: []string{"back"}, Mode: store.MatchAny, Expansions: expansions}},
+			want:   []string{"Dumbbell Row", "Pullup"},
+		},
+		{
+			name:   "excluded muscle group excludes its muscles",
+			filter: store.ExerciseFilter{Muscles: store.SetFilter{Mode: store.MatchAny, Exclude: []string{"Back"}, Expansions: expansions}},
+			want:   []string{"Barbell Bench Press", "Dumbbell Bench Press", "Push-Up"},
+		},
+		{
+			name:   "all muscles",
+			filter: store.ExerciseFilter{Muscles: store.SetFilter{Values: []string{"Chest", "triceps"}, Mode: store.MatchAll, Expansions: expansions}},
+			want:   []string{"Barbell Bench Press", "Dumbbell Bench Press", "Push-Up"},
+		},
+		{
+			name:   "exact muscles",
+			filter: store.ExerciseFilter{Muscles: store.SetFilter{Values: []string{"Chest", "Triceps"}, Mode: store.MatchExact, Expansions: expansions}},
+			want:   []string{"Barbell Bench Press", "Push-Up"},
+		},
+		{
+			name: "muscle role",
+			filter: store.ExerciseFilter{
+				Muscles:     store.SetFilter{Values: []string{"Triceps"}, Mode: store.MatchAny, Expansions: expansions},
+				MuscleRoles: []string{"primary"},
+			},
+			want: []string{"Push-Up"},
+		},
+		{
+			name:   "metadata",
+			filter: store.ExerciseFilter{Mechanics: []string{"isolation"}},
+			want:   []string{"Dumbbell Row"},
+		},
+		{
+			name: "combined",
+			filter: store.ExerciseFilter{
+				Equipment: store.SetFilter{Values: []string{"Dumbbells"}, Mode: store.MatchAny},
+				Muscles:   store.SetFilter{Values: []string{"Chest"}, Mode: store.MatchAny, Expansions: expansions},
+			},
+			want: []string{"Dumbbell Bench Press"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fromMatches []string
+			for _, ex := range exercises {
+				if tt.filter.Matches(ex) {
+					fromMatches = append(fromMatches, ex.Name)
+				}
+			}
+			fromQuery := exerciseNames(t, db, tt.filter)
+			fromMemory := exerciseNames(t, s, tt.filter)
+
+			if !slices.Equal(fromQuery, tt.want) {
+				t.Errorf("exerciseQuery selects %q, want %q\nquery: %v", fromQuery, tt.want, exerciseQuery(tt.filter))
+			}
+			if !slices.Equal(fromMatches, tt.want) {
+				t.Errorf("ExerciseFilter.Matches selects %q, want %q", fromMatches, tt.want)
+			}
+			if !slices.Equal(fromMemory, tt.want) {
+				t.Errorf("memory store selects %q, want %q", fromMemory, tt.want)
+			}
+		})
+	}
+}
+
+// filterFixture returns a memory store with a few exercises, and those
+// exercises sorted by name.
+func filterFixture(t *testing.T) (*memory.Store, []models.Exercise) {
+	t.Helper()
+	ctx := context.Background()
+	s := memory.NewStore()
+
+	for _, name := range []string{"Barbell", "Dumbbells", "Flat Bench", "None", "Pullup Bar"} {
+		if err := s.CreateOption(ctx, store.OptionEquipment, &models.Option{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	back := models.Option{Name: "Back"}
+	if err := s.CreateOption(ctx, store.OptionMuscle, &back); err != nil {
+		t.Fatal(err)
+	}
+	for _, option := range []models.Option{{Name: "Chest"}, {Name: "Triceps"}, {Name: "Shoulders"}, {Name: "Lats", ParentID: &back.ID}} {
+		if err := s.CreateOption(ctx, store.OptionMuscle, &option); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	target := func(muscle, role string) models.MuscleTarget {
+		return models.MuscleTarget{Muscle: muscle, Role: role}
+	}
+	for _, ex := range []models.Exercise{
+		{
+			Name: "Barbell Bench Press", Equipment: []string{"Barbell", "Flat Bench"}, Muscles: []string{"Chest", "Triceps"},
+			MuscleTargets: []models.MuscleTarget{target("Chest", "primary"), target("Triceps", "secondary")}, Mechanics: "compound",
+		},
+		{
+			Name: "Dumbbell Bench Press", Equipment: []string{"Dumbbells", "Flat Bench"}, Muscles: []string{"Chest", "Triceps", "Shoulders"},
+			MuscleTargets: []models.MuscleTarget{target("Chest", "primary"), target("Triceps", "secondary"), target("Shoulders", "stabilizer")}, Mechanics: "compound",
+		},
+		{
+			Name: "Dumbbell Row", Equipment: []string{"Dumbbells"}, Muscles: []string{"Lats"}, Mechanics: "isolation",
+		},
+		{
+			Name: "Pullup", Equipment: []string{"Pullup Bar"}, Muscles: []string{"Lats"}, Mechanics: "compound",
+		},
+		{
+			Name: "Push-Up", Equipment: []string{"None"}, Muscles: []string{"Chest", "Triceps"},
+			MuscleTargets: []models.MuscleTarget{target("Chest", "primary"), target("Triceps", "primary")},
+		},
+	} {
+		if err := s.CreateExercise(ctx, &ex); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	exercises, err := s.GetExercises(ctx, store.ExerciseFilter{AllOwners: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.SortFunc(exercises, func(a, b models.Exercise) int { return strings.Compare(a.Name, b.Name) })
+	return s, exercises
+}
+
+// testDatabase copies the options and exercises of s into a new database,
+// which is dropped when the test ends.
+func testDatabase(t *testing.T, s *memory.Store) *Store {
+	t.Helper()
+	ctx := context.Background()
+	db, err := InitDB(fmt.Sprintf("fitness_test_%d", time.Now().UnixNano()))
+	if err != nil {
+		t.Skipf("MongoDB is not available: %v", err)
+	}
+	t.Cleanup(func() {
+		db.DB.Drop(context.Background())
+		db.Close(context.Background())
+	})
+
+	for _, kind := range []store.OptionKind{store.OptionEquipment, store.OptionMuscle} {
+		opts, err := s.GetOptions(ctx, kind)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, option := range opts {
+			if err := db.CreateOption(ctx, kind, &option); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	exercises, err := s.GetExercises(ctx, store.ExerciseFilter{AllOwners: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ex := range exercises {
+		if err := db.CreateExercise(ctx, &ex); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return db
+}
+
+// exerciseNames returns the sorted names of the exercises s selects.
+func exerciseNames(t *testing.T, s store.ExerciseStore, filter store.ExerciseFilter) []string {
+	t.Helper()
+	found, err := s.GetExercises(context.Background(), filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, ex := range found {
+		names = append(names, ex.Name)
+	}
+	slices.Sort(names)
+	return names
+}
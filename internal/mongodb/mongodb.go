@@ -2,8 +2,10 @@ package mongodb
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"sort"
 	"time"
 
@@ -12,17 +14,31 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
-	"fitness-framework-api/internal/constants"
-	"fitness-framework-api/internal/data"
 	"fitness-framework-api/internal/models"
+	"fitness-framework-api/internal/slug"
+	"fitness-framework-api/internal/store"
 )
 
 const (
-	MongoURI       = "mongodb://localhost:27017"
-	CollectionName = "exercises"
+	MongoURI                = "mongodb://localhost:27017"
+	CollectionName          = "exercises"
+	EquipmentCollectionName = "equipment_options"
+	MusclesCollectionName   = "muscles_options"
+	UsersCollectionName     = "users"
+	SessionsCollectionName  = "sessions"
+	APIKeysCollectionName   = "api_keys"
+	AuditCollectionName     = "audit_log"
+	ProfilesCollectionName  = "equipment_profiles"
+	WorkoutsCollectionName  = "workouts"
 )
 
-func InitDB(databaseName string) (*mongo.Database, error) {
+var _ store.ExerciseStore = (*Store)(nil)
+
+type Store struct {
+	DB *mongo.Database
+}
+
+func InitDB(databaseName string) (*Store, error) {
 	clientOptions := options.Client().ApplyURI(MongoURI)
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -42,118 +58,107 @@ func InitDB(databaseName string) (*mongo.Database, error) {
 	}
 
 	slog.Info("MongoDB connection established.")
-	db := client.Database(databaseName)
-	collection := db.Collection(CollectionName)
+	return &Store{DB: client.Database(databaseName)}, nil
+}
 
-	ctx, cancel = context.WithTimeout(context.Background(), 2*time.Second)
+func (s *Store) Close(ctx context.Context) error {
+	return s.DB.Client().Disconnect(ctx)
+}
+
+func (s *Store) CreateExercise(ctx context.Context, exercise *models.Exercise) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	count, err := collection.CountDocuments(ctx, bson.D{})
-	if err != nil {
-		client.Disconnect(context.Background())
-		return nil, fmt.Errorf("error counting documents in exercises collection: %w", err)
+	if exercise.ID.IsZero() {
+		exercise.ID = primitive.NewObjectID()
+	}
+	exercise.Slug = slug.Make(exercise.Name)
+	if err := s.resolveOptionRefs(ctx, exercise); err != nil {
+		return err
 	}
 
-	if count == 0 {
-		slog.Info("Exercises collection is empty. Attempting to populate initial data from hardcoded Go data...")
-
-		equipmentCollection := db.Collection("equipment_options")
-		muscleCollection := db.Collection("muscles_options")
-
-		ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-
-		if err := equipmentCollection.Drop(ctx); err != nil {
-			slog.Error("Warning: Could not drop equipment_options collection", "error", err)
-		}
-
-		if err := muscleCollection.Drop(ctx); err != nil {
-			slog.Error("Warning: Could not drop muscles_options collection", "error", err)
+	if _, err := s.DB.Collection(CollectionName).InsertOne(ctx, exercise); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return store.ErrDuplicate
 		}
+		return fmt.Errorf("failed to insert exercise %q: %w", exercise.Name, err)
+	}
+	return nil
+}
 
-		var equipmentDocs []interface{}
-		for _, name := range constants.AllEquipmentNames {
-			equipmentDocs = append(equipmentDocs, bson.D{{Key: "_id", Value: primitive.NewObjectID()}, {Key: "name", Value: name}})
-		}
+func (s *Store) UpdateExercise(ctx context.Context, exercise models.Exercise) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
 
-		var muscleDocs []interface{}
-		for _, name := range constants.AllMuscleGroupNames {
-			muscleDocs = append(muscleDocs, bson.D{{Key: "_id", Value: primitive.NewObjectID()}, {Key: "name", Value: name}})
-		}
+	exercise.Slug = slug.Make(exercise.Name)
+	if err := s.resolveOptionRefs(ctx, &exercise); err != nil {
+		return err
+	}
 
-		if len(equipmentDocs) > 0 {
-			ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
-			defer cancel()
-			if _, err := equipmentCollection.InsertMany(ctx, equipmentDocs); err != nil {
-				client.Disconnect(context.Background())
-				return nil, fmt.Errorf("error inserting equipment options: %w", err)
-			}
-			slog.Info("Inserted equipment items from constants", "count", len(equipmentDocs))
+	result, err := s.DB.Collection(CollectionName).ReplaceOne(ctx, bson.M{"_id": exercise.ID}, exercise)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return store.ErrDuplicate
 		}
+		return fmt.Errorf("failed to update exercise %q: %w", exercise.Name, err)
+	}
+	if result.MatchedCount == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
 
-		if len(muscleDocs) > 0 {
-			ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
-			defer cancel()
-			if _, err := muscleCollection.InsertMany(ctx, muscleDocs); err != nil {
-				client.Disconnect(context.Background())
-				return nil, fmt.Errorf("error inserting muscle options: %w", err)
-			}
-			slog.Info("Inserted muscle items from constants", "count", len(muscleDocs))
-		}
+func (s *Store) DeleteExercise(ctx context.Context, id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
 
-		var documents []interface{}
-		for _, rawEx := range data.AllRawExercises {
-			validEquipment := []string{}
-			for _, eqName := range rawEx.Equipment {
-				if constants.IsValidEquipment(eqName) {
-					validEquipment = append(validEquipment, eqName)
-				} else {
-					slog.Error("Warning: Invalid equipment '%s' for exercise '%s'. Skipping", eqName, rawEx.Name)
-				}
-			}
-
-			validMuscles := []string{}
-			for _, muscleName := range rawEx.Muscles {
-				if constants.IsValidMuscleGroup(muscleName) {
-					validMuscles = append(validMuscles, muscleName)
-				} else {
-					slog.Error("Warning: Invalid muscle group '%s' for exercise '%s'. Skipping", muscleName, rawEx.Name)
-				}
-			}
-
-			doc := models.Exercise{
-				ID:        primitive.NewObjectID(),
-				Name:      rawEx.Name,
-				Equipment: validEquipment,
-				Muscles:   validMuscles,
-			}
-			documents = append(documents, doc)
-		}
+	result, err := s.DB.Collection(CollectionName).DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete exercise %s: %w", id.Hex(), err)
+	}
+	if result.DeletedCount == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
 
-		if len(documents) > 0 {
-			ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
-			defer cancel()
-			_, err := collection.InsertMany(ctx, documents)
-			if err != nil {
-				client.Disconnect(context.Background())
-				return nil, fmt.Errorf("error inserting initial exercises: %w", err)
-			}
-			slog.Info("Successfully populated exercises", "count", len(documents))
-		}
+func (s *Store) AddExerciseMedia(ctx context.Context, exerciseID primitive.ObjectID, media models.Media) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
 
-	} else {
-		slog.Info("Exercises collection already contains documents", "count", count)
+	result, err := s.DB.Collection(CollectionName).UpdateByID(ctx, exerciseID, bson.M{"$push": bson.M{"media": media}})
+	if err != nil {
+		return fmt.Errorf("failed to add media to exercise %s: %w", exerciseID.Hex(), err)
 	}
+	if result.MatchedCount == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
 
-	return db, nil
+func (s *Store) RemoveExerciseMedia(ctx context.Context, exerciseID, mediaID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := s.DB.Collection(CollectionName).UpdateOne(ctx,
+		bson.M{"_id": exerciseID, "media._id": mediaID},
+		bson.M{"$pull": bson.M{"media": bson.M{"_id": mediaID}}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to remove media %s from exercise %s: %w", mediaID.Hex(), exerciseID.Hex(), err)
+	}
+	if result.MatchedCount == 0 {
+		return store.ErrNotFound
+	}
+	return nil
 }
 
-func GetExercises(db *mongo.Database) ([]models.Exercise, error) {
-	collection := db.Collection(CollectionName)
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (s *Store) GetExercises(ctx context.Context, filter store.ExerciseFilter) ([]models.Exercise, error) {
+	collection := s.DB.Collection(CollectionName)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	cursor, err := collection.Find(ctx, bson.D{})
+	cursor, err := collection.Find(ctx, exerciseQuery(filter), options.Find().SetCollation(CaseInsensitive))
 	if err != nil {
 		return nil, fmt.Errorf("failed to find exercises: %w", err)
 	}
@@ -164,92 +169,160 @@ func GetExercises(db *mongo.Database) ([]models.Exercise, error) {
 		return nil, fmt.Errorf("failed to decode exercises: %w", err)
 	}
 
+	// Typo-tolerant search cannot be expressed as a query, so matches are
+	// picked from the otherwise filtered results.
+	if filter.Query != "" {
+		exercises = slices.DeleteFunc(exercises, func(ex models.Exercise) bool { return !store.MatchesQuery(ex, filter.Query) })
+	}
 	for i := range exercises {
-		sort.Strings(exercises[i].Equipment)
-		sort.Strings(exercises[i].Muscles)
+		store.SortOptionRefs(&exercises[i])
 	}
 
 	return exercises, nil
 }
 
-func GetUniqueExerciseNames(db *mongo.Database) ([]string, error) {
-	collection := db.Collection(CollectionName)
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (s *Store) ListExercises(ctx context.Context, filter store.ExerciseFilter, page store.PageRequest) (*store.ExercisePage, error) {
+	if filter.Query != "" {
+		exercises, err := s.GetExercises(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		return store.PaginateSearch(exercises, filter.Query, page), nil
+	}
+
+	collection := s.DB.Collection(CollectionName)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	distinctNames, err := collection.Distinct(ctx, "name", bson.D{})
+	base := exerciseQuery(filter)
+	total, err := collection.CountDocuments(ctx, base, options.Count().SetCollation(CaseInsensitive))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get distinct exercise names: %w", err)
+		return nil, fmt.Errorf("failed to count exercises: %w", err)
 	}
 
-	var names []string
-	for _, doc := range distinctNames {
-		if name, ok := doc.(string); ok {
-			names = append(names, name)
-		}
+	result := &store.ExercisePage{Total: total}
+	key := sortKeys[page.Sort]
+	direction := 1
+	if page.Desc {
+		direction = -1
+	}
+	forward, backward := "$gt", "$lt"
+	if page.Desc {
+		forward, backward = backward, forward
 	}
-	sort.Strings(names)
-
-	return names, nil
-}
-
-func GetUniqueMuscles(db *mongo.Database) ([]string, error) {
-	collection := db.Collection("muscles_options")
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
 
-	cursor, err := collection.Find(ctx, bson.D{}, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+	query := base
+	findOptions := options.Find().SetCollation(CaseInsensitive)
+	reverse := false
+	switch {
+	case page.After != nil:
+		query = bson.D{{Key: "$and", Value: bson.A{base, keysetQuery(key, page.After, forward)}}}
+		// Anything not after the cursor, including the cursor itself, is on
+		// an earlier page.
+		result.HasPrev, err = s.exists(ctx, bson.D{{Key: "$and", Value: bson.A{base, bson.M{"$nor": bson.A{keysetQuery(key, page.After, forward)}}}}})
+	case page.Before != nil:
+		query = bson.D{{Key: "$and", Value: bson.A{base, keysetQuery(key, page.Before, backward)}}}
+		direction = -direction
+		reverse = true
+		result.HasNext, err = s.exists(ctx, bson.D{{Key: "$and", Value: bson.A{base, bson.M{"$nor": bson.A{keysetQuery(key, page.Before, backward)}}}}})
+	default:
+		findOptions.SetSkip(int64(page.Offset))
+		result.HasPrev = page.Offset > 0
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to find unique muscles: %w", err)
+		return nil, err
+	}
+	findOptions.SetSort(sortSpec(key, direction))
+	if page.Limit > 0 {
+		findOptions.SetLimit(int64(page.Limit + 1))
 	}
 
+	cursor, err := collection.Find(ctx, query, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find exercises: %w", err)
+	}
 	defer cursor.Close(ctx)
 
-	var muscles []string
-	for cursor.Next(ctx) {
-		var result struct {
-			Name string `bson:"name"`
-		}
-		if err := cursor.Decode(&result); err != nil {
-			slog.Error("Warning: Failed to decode muscle option", "error", err)
-			continue
+	var exercises []models.Exercise
+	if err = cursor.All(ctx, &exercises); err != nil {
+		return nil, fmt.Errorf("failed to decode exercises: %w", err)
+	}
+
+	if page.Limit > 0 && len(exercises) > page.Limit {
+		exercises = exercises[:page.Limit]
+		if reverse {
+			result.HasPrev = true
+		} else {
+			result.HasNext = true
 		}
-		muscles = append(muscles, result.Name)
+	}
+	if reverse {
+		slices.Reverse(exercises)
 	}
 
-	if err := cursor.Err(); err != nil {
-		return nil, fmt.Errorf("error during unique muscles iteration: %w", err)
+	for i := range exercises {
+		store.SortOptionRefs(&exercises[i])
 	}
+	result.Exercises = exercises
 
-	return muscles, nil
+	return result, nil
 }
 
-func GetUniqueEquipment(db *mongo.Database) ([]string, error) {
-	collection := db.Collection("equipment_options")
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// exists reports whether any exercise matches the query.
+func (s *Store) exists(ctx context.Context, query bson.D) (bool, error) {
+	count, err := s.DB.Collection(CollectionName).CountDocuments(ctx, query, options.Count().SetCollation(CaseInsensitive).SetLimit(1))
+	if err != nil {
+		return false, fmt.Errorf("failed to count exercises: %w", err)
+	}
+	return count > 0, nil
+}
+
+func (s *Store) GetExercise(ctx context.Context, id primitive.ObjectID) (*models.Exercise, error) {
+	return s.findExercise(ctx, bson.M{"_id": id})
+}
+
+func (s *Store) GetExerciseBySlug(ctx context.Context, exerciseSlug string) (*models.Exercise, error) {
+	return s.findExercise(ctx, bson.M{"slug": exerciseSlug, "owner_id": nil})
+}
+
+func (s *Store) FindExerciseByName(ctx context.Context, name string) (*models.Exercise, error) {
+	return s.findExercise(ctx, bson.M{"name": name, "owner_id": nil})
+}
+
+func (s *Store) findExercise(ctx context.Context, query bson.M) (*models.Exercise, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	cursor, err := collection.Find(ctx, bson.D{}, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+	var exercise models.Exercise
+	err := s.DB.Collection(CollectionName).FindOne(ctx, query, options.FindOne().SetCollation(CaseInsensitive)).Decode(&exercise)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, store.ErrNotFound
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to find unique equipment: %w", err)
+		return nil, fmt.Errorf("failed to find exercise: %w", err)
 	}
-	defer cursor.Close(ctx)
 
-	var equipment []string
-	for cursor.Next(ctx) {
-		var result struct {
-			Name string `bson:"name"`
-		}
-		if err := cursor.Decode(&result); err != nil {
-			slog.Error("Warning: Failed to decode equipment option", "error", err)
-			continue
-		}
-		equipment = append(equipment, result.Name)
+	store.SortOptionRefs(&exercise)
+	return &exercise, nil
+}
+
+func (s *Store) GetUniqueExerciseNames(ctx context.Context) ([]string, error) {
+	collection := s.DB.Collection(CollectionName)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	distinctNames, err := collection.Distinct(ctx, "name", bson.M{"owner_id": nil})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get distinct exercise names: %w", err)
 	}
 
-	if err := cursor.Err(); err != nil {
-		return nil, fmt.Errorf("error during unique equipment iteration: %w", err)
+	var names []string
+	for _, doc := range distinctNames {
+		if name, ok := doc.(string); ok {
+			names = append(names, name)
+		}
 	}
+	sort.Strings(names)
 
-	return equipment, nil
+	return names, nil
 }
@@ -0,0 +1,213 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"fitness-framework-api/internal/models"
+	"fitness-framework-api/internal/store"
+)
+
+func (s *Store) GetOptions(ctx context.Context, kind store.OptionKind) ([]models.Option, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	cursor, err := s.optionCollection(kind).Find(ctx, bson.D{}, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find %s options: %w", kind, err)
+	}
+	defer cursor.Close(ctx)
+
+	var opts []models.Option
+	if err := cursor.All(ctx, &opts); err != nil {
+		return nil, fmt.Errorf("failed to decode %s options: %w", kind, err)
+	}
+	return opts, nil
+}
+
+func (s *Store) CreateOption(ctx context.Context, kind store.OptionKind, option *models.Option) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if option.ID.IsZero() {
+		option.ID = primitive.NewObjectID()
+	}
+
+	if _, err := s.optionCollection(kind).InsertOne(ctx, option); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return store.ErrDuplicate
+		}
+		return fmt.Errorf("failed to add %s option %q: %w", kind, option.Name, err)
+	}
+	return nil
+}
+
+func (s *Store) UpdateOption(ctx context.Context, kind store.OptionKind, option models.Option) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := s.optionCollection(kind).ReplaceOne(ctx, bson.M{"_id": option.ID}, option)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return store.ErrDuplicate
+		}
+		return fmt.Errorf("failed to update %s option %q: %w", kind, option.Name, err)
+	}
+	if result.MatchedCount == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+func (s *Store) DeleteOption(ctx context.Context, kind store.OptionKind, id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := s.optionCollection(kind).DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("failed to remove %s option %s: %w", kind, id.Hex(), err)
+	}
+	if result.DeletedCount == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+func (s *Store) CountOptionUsage(ctx context.Context, kind store.OptionKind, id primitive.ObjectID) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, idField := exerciseFields(kind)
+	count, err := s.DB.Collection(CollectionName).CountDocuments(ctx, bson.M{idField: id})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count exercises using %s option %s: %w", kind, id.Hex(), err)
+	}
+	return count, nil
+}
+
+func (s *Store) ReplaceOptionInExercises(ctx context.Context, kind store.OptionKind, from models.Option, into *models.Option) (int64, error) {
+	collection := s.DB.Collection(CollectionName)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	nameField, idField := exerciseFields(kind)
+	query := bson.M{idField: from.ID}
+
+	if kind == store.OptionMuscle {
+		if err := s.replaceMuscleTargets(ctx, from, into); err != nil {
+			return 0, err
+		}
+	}
+
+	if into != nil && into.ID == from.ID {
+		result, err := collection.UpdateMany(ctx, query,
+			bson.M{"$set": bson.M{nameField + ".$[name]": into.Name}},
+			options.Update().SetArrayFilters(options.ArrayFilters{Filters: bson.A{bson.M{"name": from.Name}}}),
+		)
+		if err != nil {
+			return 0, fmt.Errorf("failed to rename %s option %q on exercises: %w", kind, from.Name, err)
+		}
+		return result.ModifiedCount, nil
+	}
+
+	if into != nil {
+		_, err := collection.UpdateMany(ctx, query, bson.M{"$addToSet": bson.M{nameField: into.Name, idField: into.ID}})
+		if err != nil {
+			return 0, fmt.Errorf("failed to add %s option %q to exercises: %w", kind, into.Name, err)
+		}
+	}
+
+	result, err := collection.UpdateMany(ctx, query, bson.M{"$pull": bson.M{nameField: from.Name, idField: from.ID}})
+	if err != nil {
+		return 0, fmt.Errorf("failed to remove %s option %q from exercises: %w", kind, from.Name, err)
+	}
+	return result.ModifiedCount, nil
+}
+
+func (s *Store) replaceMuscleTargets(ctx context.Context, from models.Option, into *models.Option) error {
+	collection := s.DB.Collection(CollectionName)
+	pullFrom := bson.M{"$pull": bson.M{"muscle_targets": bson.M{"muscle_id": from.ID}}}
+
+	if into == nil {
+		if _, err := collection.UpdateMany(ctx, bson.M{"muscle_ids": from.ID}, pullFrom); err != nil {
+			return fmt.Errorf("failed to remove muscle targets for %q: %w", from.Name, err)
+		}
+		return nil
+	}
+
+	if into.ID != from.ID {
+		if _, err := collection.UpdateMany(ctx, bson.M{"muscle_ids": bson.M{"$all": bson.A{from.ID, into.ID}}}, pullFrom); err != nil {
+			return fmt.Errorf("failed to remove muscle targets for %q: %w", from.Name, err)
+		}
+	}
+
+	_, err := collection.UpdateMany(ctx, bson.M{"muscle_targets.muscle_id": from.ID},
+		bson.M{"$set": bson.M{"muscle_targets.$[target].muscle": into.Name, "muscle_targets.$[target].muscle_id": into.ID}},
+		options.Update().SetArrayFilters(options.ArrayFilters{Filters: bson.A{bson.M{"target.muscle_id": from.ID}}}),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update muscle targets for %q: %w", from.Name, err)
+	}
+	return nil
+}
+
+func (s *Store) GetUniqueMuscles(ctx context.Context) ([]string, error) {
+	return s.getOptionNames(ctx, store.OptionMuscle)
+}
+
+func (s *Store) GetUniqueEquipment(ctx context.Context) ([]string, error) {
+	return s.getOptionNames(ctx, store.OptionEquipment)
+}
+
+func (s *Store) getOptionNames(ctx context.Context, kind store.OptionKind) ([]string, error) {
+	opts, err := s.GetOptions(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(opts))
+	for _, option := range opts {
+		names = append(names, option.Name)
+	}
+	return names, nil
+}
+
+func (s *Store) resolveOptionRefs(ctx context.Context, exercise *models.Exercise) error {
+	equipment, err := s.GetOptions(ctx, store.OptionEquipment)
+	if err != nil {
+		return err
+	}
+	if exercise.Equipment, exercise.EquipmentIDs, err = store.ResolveOptions(exercise.Equipment, equipment); err != nil {
+		return err
+	}
+
+	muscles, err := s.GetOptions(ctx, store.OptionMuscle)
+	if err != nil {
+		return err
+	}
+	if exercise.Muscles, exercise.MuscleIDs, err = store.ResolveOptions(exercise.Muscles, muscles); err != nil {
+		return err
+	}
+	store.AlignMuscleTargets(exercise)
+	return nil
+}
+
+func (s *Store) optionCollection(kind store.OptionKind) *mongo.Collection {
+	if kind == store.OptionMuscle {
+		return s.DB.Collection(MusclesCollectionName)
+	}
+	return s.DB.Collection(EquipmentCollectionName)
+}
+
+func exerciseFields(kind store.OptionKind) (string, string) {
+	if kind == store.OptionMuscle {
+		return "muscles", "muscle_ids"
+	}
+	return "equipment", "equipment_ids"
+}
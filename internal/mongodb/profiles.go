@@ -0,0 +1,110 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"fitness-framework-api/internal/models"
+	"fitness-framework-api/internal/slug"
+	"fitness-framework-api/internal/store"
+)
+
+var _ store.ProfileStore = (*Store)(nil)
+
+func (s *Store) GetEquipmentProfiles(ctx context.Context, userID primitive.ObjectID) ([]models.EquipmentProfile, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	cursor, err := s.DB.Collection(ProfilesCollectionName).Find(ctx, bson.M{"user_id": userID}, options.Find().SetSort(bson.D{{Key: "slug", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find equipment profiles: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var profiles []models.EquipmentProfile
+	if err := cursor.All(ctx, &profiles); err != nil {
+		return nil, fmt.Errorf("failed to decode equipment profiles: %w", err)
+	}
+	return profiles, nil
+}
+
+func (s *Store) CreateEquipmentProfile(ctx context.Context, profile *models.EquipmentProfile) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if profile.ID.IsZero() {
+		profile.ID = primitive.NewObjectID()
+	}
+	profile.Slug = slug.Make(profile.Name)
+	if _, err := s.DB.Collection(ProfilesCollectionName).InsertOne(ctx, profile); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return store.ErrDuplicate
+		}
+		return fmt.Errorf("failed to insert equipment profile %q: %w", profile.Name, err)
+	}
+	return nil
+}
+
+func (s *Store) UpdateEquipmentProfile(ctx context.Context, profile models.EquipmentProfile) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	profile.Slug = slug.Make(profile.Name)
+	filter := bson.M{"_id": profile.ID, "user_id": profile.UserID}
+	result, err := s.DB.Collection(ProfilesCollectionName).ReplaceOne(ctx, filter, profile)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return store.ErrDuplicate
+		}
+		return fmt.Errorf("failed to update equipment profile %s: %w", profile.ID.Hex(), err)
+	}
+	if result.MatchedCount == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+func (s *Store) DeleteEquipmentProfile(ctx context.Context, userID, id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := s.DB.Collection(ProfilesCollectionName).DeleteOne(ctx, bson.M{"_id": id, "user_id": userID})
+	if err != nil {
+		return fmt.Errorf("failed to delete equipment profile %s: %w", id.Hex(), err)
+	}
+	if result.DeletedCount == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+func (s *Store) MergeProfileEquipment(ctx context.Context, from, into primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	collection := s.DB.Collection(ProfilesCollectionName)
+	if _, err := collection.UpdateMany(ctx, bson.M{"equipment_ids": bson.M{"$all": bson.A{from, into}}}, bson.M{"$pull": bson.M{"equipment_ids": from}}); err != nil {
+		return fmt.Errorf("failed to merge equipment in profiles: %w", err)
+	}
+	if _, err := collection.UpdateMany(ctx, bson.M{"equipment_ids": from}, bson.M{"$set": bson.M{"equipment_ids.$": into}}); err != nil {
+		return fmt.Errorf("failed to merge equipment in profiles: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) RemoveProfileEquipment(ctx context.Context, id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	collection := s.DB.Collection(ProfilesCollectionName)
+	if _, err := collection.UpdateMany(ctx, bson.M{"equipment_ids": id}, bson.M{"$pull": bson.M{"equipment_ids": id}}); err != nil {
+		return fmt.Errorf("failed to remove equipment from profiles: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"fitness-framework-api/internal/models"
+	"fitness-framework-api/internal/store"
+)
+
+var _ store.WorkoutStore = (*Store)(nil)
+
+func (s *Store) ListWorkouts(ctx context.Context, filter store.WorkoutFilter) ([]models.Workout, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := bson.M{"user_id": filter.UserID}
+	started := bson.M{}
+	if filter.From != nil {
+		started["$gte"] = *filter.From
+	}
+	if filter.To != nil {
+		started["$lt"] = *filter.To
+	}
+	if len(started) > 0 {
+		query["started_at"] = started
+	}
+	if filter.ExerciseID != nil {
+		query["exercises.exercise_id"] = *filter.ExerciseID
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "started_at", Value: -1}, {Key: "_id", Value: -1}})
+	if filter.Limit > 0 {
+		opts.SetLimit(int64(filter.Limit))
+	}
+
+	cursor, err := s.DB.Collection(WorkoutsCollectionName).Find(ctx, query, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find workouts: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var workouts []models.Workout
+	if err := cursor.All(ctx, &workouts); err != nil {
+		return nil, fmt.Errorf("failed to decode workouts: %w", err)
+	}
+	return workouts, nil
+}
+
+func (s *Store) GetWorkout(ctx context.Context, userID, id primitive.ObjectID) (*models.Workout, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var workout models.Workout
+	err := s.DB.Collection(WorkoutsCollectionName).FindOne(ctx, bson.M{"_id": id, "user_id": userID}).Decode(&workout)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, store.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find workout %s: %w", id.Hex(), err)
+	}
+	return &workout, nil
+}
+
+func (s *Store) CreateWorkout(ctx context.Context, workout *models.Workout) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if workout.ID.IsZero() {
+		workout.ID = primitive.NewObjectID()
+	}
+	if _, err := s.DB.Collection(WorkoutsCollectionName).InsertOne(ctx, workout); err != nil {
+		return fmt.Errorf("failed to insert workout: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) UpdateWorkout(ctx context.Context, workout models.Workout) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": workout.ID, "user_id": workout.UserID}
+	result, err := s.DB.Collection(WorkoutsCollectionName).ReplaceOne(ctx, filter, workout)
+	if err != nil {
+		return fmt.Errorf("failed to update workout %s: %w", workout.ID.Hex(), err)
+	}
+	if result.MatchedCount == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+func (s *Store) DeleteWorkout(ctx context.Context, userID, id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := s.DB.Collection(WorkoutsCollectionName).DeleteOne(ctx, bson.M{"_id": id, "user_id": userID})
+	if err != nil {
+		return fmt.Errorf("failed to delete workout %s: %w", id.Hex(), err)
+	}
+	if result.DeletedCount == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
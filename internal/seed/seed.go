@@ -0,0 +1,224 @@
+package seed
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"fitness-framework-api/internal/constants"
+	"fitness-framework-api/internal/models"
+	"fitness-framework-api/internal/slug"
+)
+
+const (
+	DefaultPath = "./data/exercises.json"
+)
+
+type ValidationError struct {
+	Path     string
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("seed file %s has %d problem(s):\n  %s", e.Path, len(e.Problems), strings.Join(e.Problems, "\n  "))
+}
+
+func Load(path string) ([]models.RawExercise, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not get absolute path for %s: %w", path, err)
+	}
+
+	slog.Info("Attempting to load seed data from", "path", absPath)
+
+	raw, err := os.ReadFile(absPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read seed file %s: %w", path, err)
+	}
+
+	var exercises []models.RawExercise
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		err = json.Unmarshal(raw, &exercises)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(raw, &exercises)
+	default:
+		return nil, fmt.Errorf("unsupported seed file extension %q for %s", ext, path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal seed data from %s: %w", path, err)
+	}
+
+	if problems := Validate(exercises); len(problems) > 0 {
+		return nil, &ValidationError{Path: path, Problems: problems}
+	}
+
+	slog.Info("Successfully loaded seed data", "exercises", len(exercises))
+	return exercises, nil
+}
+
+func Validate(exercises []models.RawExercise) []string {
+	var problems []string
+	seen := make(map[string]int)
+
+	for i, ex := range exercises {
+		entry := fmt.Sprintf("entry %d (%q)", i+1, ex.Name)
+
+		name := strings.TrimSpace(ex.Name)
+		if name == "" {
+			problems = append(problems, fmt.Sprintf("entry %d: name is required", i+1))
+		} else if slug.Make(name) == "" {
+			problems = append(problems, fmt.Sprintf("%s: name must contain at least one letter or digit", entry))
+		} else if first, ok := seen[slug.Make(name)]; ok {
+			problems = append(problems, fmt.Sprintf("%s: duplicate name or slug, first defined in entry %d", entry, first))
+		} else {
+			seen[slug.Make(name)] = i + 1
+		}
+
+		for j, alias := range ex.Aliases {
+			if strings.TrimSpace(alias) == "" {
+				problems = append(problems, fmt.Sprintf("%s: alias %d is empty", entry, j+1))
+			} else if strings.EqualFold(strings.TrimSpace(alias), name) {
+				problems = append(problems, fmt.Sprintf("%s: alias %q repeats the name", entry, alias))
+			}
+		}
+		problems = append(problems, duplicates(entry, "alias", ex.Aliases)...)
+
+		if len(ex.Equipment) == 0 {
+			problems = append(problems, fmt.Sprintf("%s: at least one equipment entry is required", entry))
+		}
+		for _, eqName := range ex.Equipment {
+			if !constants.IsValidEquipment(eqName) {
+				problems = append(problems, fmt.Sprintf("%s: unknown equipment %q", entry, eqName))
+			}
+		}
+		problems = append(problems, duplicates(entry, "equipment", ex.Equipment)...)
+
+		if len(ex.Muscles) == 0 {
+			problems = append(problems, fmt.Sprintf("%s: at least one primary muscle group is required", entry))
+		}
+		allMuscles := slices.Concat(ex.Muscles, ex.SecondaryMuscles, ex.StabilizerMuscles)
+		for _, muscleName := range allMuscles {
+			if !constants.IsValidMuscle(muscleName) {
+				problems = append(problems, fmt.Sprintf("%s: unknown muscle %q", entry, muscleName))
+			}
+		}
+		problems = append(problems, duplicates(entry, "muscle group", allMuscles)...)
+
+		for _, attr := range []struct {
+			field string
+			value string
+			valid func(string) bool
+		}{
+			{"mechanics", ex.Mechanics, constants.IsValidMechanics},
+			{"force", ex.Force, constants.IsValidForce},
+			{"movementPattern", ex.MovementPattern, constants.IsValidMovementPattern},
+			{"laterality", ex.Laterality, constants.IsValidLaterality},
+			{"difficulty", ex.Difficulty, constants.IsValidDifficulty},
+		} {
+			if attr.value != "" && !attr.valid(attr.value) {
+				problems = append(problems, fmt.Sprintf("%s: unknown %s %q", entry, attr.field, attr.value))
+			}
+		}
+
+		for _, text := range []struct {
+			field string
+			lines []string
+		}{
+			{"instructions", ex.Instructions},
+			{"cues", ex.Cues},
+			{"commonMistakes", ex.CommonMistakes},
+			{"safetyNotes", ex.SafetyNotes},
+		} {
+			for j, line := range text.lines {
+				if strings.TrimSpace(line) == "" {
+					problems = append(problems, fmt.Sprintf("%s: %s line %d is empty", entry, text.field, j+1))
+				}
+			}
+		}
+	}
+
+	problems = append(problems, variationProblems(exercises)...)
+	return problems
+}
+
+func variationProblems(exercises []models.RawExercise) []string {
+	var problems []string
+	parents := make(map[string]string, len(exercises))
+	for _, ex := range exercises {
+		parents[naturalKey(ex.Name)] = naturalKey(ex.VariationOf)
+	}
+
+	for i, ex := range exercises {
+		if ex.VariationOf == "" {
+			continue
+		}
+		entry := fmt.Sprintf("entry %d (%q)", i+1, ex.Name)
+		if _, ok := parents[naturalKey(ex.VariationOf)]; !ok {
+			problems = append(problems, fmt.Sprintf("%s: variationOf %q is not an exercise in the seed file", entry, ex.VariationOf))
+			continue
+		}
+
+		visited := map[string]bool{naturalKey(ex.Name): true}
+		for parent := naturalKey(ex.VariationOf); parent != ""; parent = parents[parent] {
+			if visited[parent] {
+				problems = append(problems, fmt.Sprintf("%s: variationOf %q leads back to the exercise itself", entry, ex.VariationOf))
+				break
+			}
+			visited[parent] = true
+		}
+	}
+	return problems
+}
+
+func ToExercise(raw models.RawExercise) models.Exercise {
+	ex := models.Exercise{
+		Name:               raw.Name,
+		Aliases:            raw.Aliases,
+		Equipment:          raw.Equipment,
+		Mechanics:          strings.ToLower(raw.Mechanics),
+		Force:              strings.ToLower(raw.Force),
+		MovementPattern:    strings.ToLower(raw.MovementPattern),
+		Laterality:         strings.ToLower(raw.Laterality),
+		Difficulty:         strings.ToLower(raw.Difficulty),
+		BodyweightLoadable: raw.BodyweightLoadable,
+		Description:        raw.Description,
+		Instructions:       raw.Instructions,
+		Cues:               raw.Cues,
+		CommonMistakes:     raw.CommonMistakes,
+		SafetyNotes:        raw.SafetyNotes,
+	}
+	for _, group := range []struct {
+		role    string
+		muscles []string
+	}{
+		{constants.MuscleRolePrimary, raw.Muscles},
+		{constants.MuscleRoleSecondary, raw.SecondaryMuscles},
+		{constants.MuscleRoleStabilizer, raw.StabilizerMuscles},
+	} {
+		for _, muscle := range group.muscles {
+			ex.Muscles = append(ex.Muscles, muscle)
+			ex.MuscleTargets = append(ex.MuscleTargets, models.MuscleTarget{Muscle: muscle, Role: group.role})
+		}
+	}
+	return ex
+}
+
+func duplicates(entry, kind string, values []string) []string {
+	var problems []string
+	seen := make(map[string]bool)
+	for _, v := range values {
+		key := strings.ToLower(v)
+		if seen[key] {
+			problems = append(problems, fmt.Sprintf("%s: %s %q listed more than once", entry, kind, v))
+		}
+		seen[key] = true
+	}
+	return problems
+}
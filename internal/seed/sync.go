@@ -0,0 +1,499 @@
+package seed
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fitness-framework-api/internal/constants"
+	"fitness-framework-api/internal/models"
+	"fitness-framework-api/internal/store"
+)
+
+type SyncOptions struct {
+	DryRun bool
+	Prune  bool
+}
+
+// ChangeSet lists the entries a sync touched. Kept holds the entries the
+// seed would change or remove but that were changed through the API or are
+// still in use.
+type ChangeSet struct {
+	Added     []string `json:"added"`
+	Changed   []string `json:"changed"`
+	Removed   []string `json:"removed"`
+	Kept      []string `json:"kept"`
+	Unchanged int      `json:"unchanged"`
+}
+
+type SyncReport struct {
+	DryRun    bool      `json:"dryRun"`
+	Pruned    bool      `json:"pruned"`
+	Exercises ChangeSet `json:"exercises"`
+	Equipment ChangeSet `json:"equipment"`
+	Muscles   ChangeSet `json:"muscles"`
+}
+
+func (r *SyncReport) Log() {
+	for _, section := range []struct {
+		name    string
+		changes ChangeSet
+	}{
+		{"exercises", r.Exercises},
+		{"equipment", r.Equipment},
+		{"muscles", r.Muscles},
+	} {
+		slog.Info("Catalog sync",
+			"collection", section.name,
+			"dryRun", r.DryRun,
+			"added", len(section.changes.Added),
+			"changed", len(section.changes.Changed),
+			"removed", len(section.changes.Removed),
+			"kept", len(section.changes.Kept),
+			"pruned", r.Pruned,
+			"unchanged", section.changes.Unchanged,
+		)
+	}
+}
+
+// Sync brings the catalog in line with the seed. Only documents the sync
+// created, or found identical to the seed, are updated or removed; each
+// records its seed entry and the hash of what the sync wrote. Documents
+// changed through the API since then no longer match the hash and are kept
+// as they are.
+func Sync(ctx context.Context, s store.Store, exercises []models.RawExercise, opts SyncOptions) (*SyncReport, error) {
+	report := &SyncReport{DryRun: opts.DryRun, Pruned: opts.Prune && !opts.DryRun}
+	refs := make(map[store.OptionKind]map[string]models.Option)
+
+	var err error
+	report.Equipment, refs[store.OptionEquipment], err = syncOptions(ctx, s, store.OptionEquipment, constants.AllEquipmentNames, constants.EquipmentCategories, nil, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	muscles := slices.Concat(constants.AllMuscleGroupNames, constants.AllMuscleNames)
+	report.Muscles, refs[store.OptionMuscle], err = syncOptions(ctx, s, store.OptionMuscle, muscles, constants.MuscleGroupCategories, constants.MuscleParents, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.GetExercises(ctx, store.ExerciseFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("error loading exercises: %w", err)
+	}
+	report.Exercises, err = syncExercises(ctx, s, existing, exercises, refs, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// syncOptions syncs the options of kind with the wanted names. It returns
+// the option that holds each wanted name; in a dry run, options that would
+// be added have no ID.
+func syncOptions(ctx context.Context, s store.Store, kind store.OptionKind, wanted []string, categories, parents map[string]string, opts SyncOptions) (ChangeSet, map[string]models.Option, error) {
+	var changes ChangeSet
+	refs := make(map[string]models.Option, len(wanted))
+
+	existing, err := s.GetOptions(ctx, kind)
+	if err != nil {
+		return changes, nil, fmt.Errorf("error loading %s options: %w", kind, err)
+	}
+	current := slices.Clone(existing)
+	claimed := make(map[primitive.ObjectID]bool)
+
+	for _, name := range wanted {
+		want := models.Option{Name: name, Category: categories[name]}
+		wantHash := optionHash(want, parents[name])
+
+		var parentID *primitive.ObjectID
+		if parent, ok := refs[parents[name]]; ok && !parent.ID.IsZero() {
+			parentID = &parent.ID
+		}
+
+		i := slices.IndexFunc(current, func(o models.Option) bool {
+			return o.Seed != nil && o.Seed.Key == name || o.Seed == nil && o.Name == name && !claimed[o.ID]
+		})
+		if i < 0 {
+			changes.Added = append(changes.Added, name)
+			want.ParentID = parentID
+			want.Seed = &models.SeedRef{Key: name, Hash: wantHash}
+			if !opts.DryRun {
+				if err := s.CreateOption(ctx, kind, &want); err != nil {
+					return changes, nil, fmt.Errorf("error adding %s option %q: %w", kind, name, err)
+				}
+				current = append(current, want)
+			}
+			refs[name] = want
+			continue
+		}
+
+		option := current[i]
+		claimed[option.ID] = true
+		refs[name] = option
+		currentHash := optionHash(option, optionParentKey(current, option))
+		switch {
+		case currentHash == wantHash:
+			changes.Unchanged++
+			if option.Seed != nil && option.Seed.Hash == wantHash || opts.DryRun {
+				continue
+			}
+		case option.Seed == nil || currentHash != option.Seed.Hash:
+			changes.Kept = append(changes.Kept, name)
+			continue
+		default:
+			changes.Changed = append(changes.Changed, name)
+			if opts.DryRun {
+				continue
+			}
+			option.Name = want.Name
+			option.Category = want.Category
+			option.ParentID = parentID
+		}
+
+		option.Seed = &models.SeedRef{Key: name, Hash: wantHash}
+		if err := s.UpdateOption(ctx, kind, option); err != nil {
+			return changes, nil, fmt.Errorf("error updating %s option %q: %w", kind, name, err)
+		}
+		current[i] = option
+		refs[name] = option
+	}
+
+	for _, option := range existing {
+		if option.Seed == nil || claimed[option.ID] {
+			continue
+		}
+		owned := optionHash(option, optionParentKey(current, option)) == option.Seed.Hash
+		hasChildren := slices.ContainsFunc(current, func(o models.Option) bool { return o.ParentID != nil && *o.ParentID == option.ID })
+		usage, err := s.CountOptionUsage(ctx, kind, option.ID)
+		if err != nil {
+			return changes, nil, fmt.Errorf("error counting usage of %s option %q: %w", kind, option.Name, err)
+		}
+		if !owned || hasChildren || usage > 0 {
+			changes.Kept = append(changes.Kept, option.Name)
+			continue
+		}
+
+		changes.Removed = append(changes.Removed, option.Name)
+		if opts.DryRun || !opts.Prune {
+			continue
+		}
+		if err := store.DeleteOption(ctx, s, s, kind, option.ID); err != nil {
+			return changes, nil, fmt.Errorf("error removing %s option %q: %w", kind, option.Name, err)
+		}
+	}
+
+	return changes, refs, nil
+}
+
+func syncExercises(ctx context.Context, s store.ExerciseStore, existing []models.Exercise, wanted []models.RawExercise, refs map[store.OptionKind]map[string]models.Option, opts SyncOptions) (ChangeSet, error) {
+	var changes ChangeSet
+
+	claimed := make(map[primitive.ObjectID]bool)
+	ids := make(map[string]primitive.ObjectID, len(wanted))
+	for _, rawEx := range parentsFirst(wanted) {
+		key := naturalKey(rawEx.Name)
+		parentKey := naturalKey(rawEx.VariationOf)
+		want := seedExercise(rawEx, refs)
+		wantHash := exerciseHash(want, parentKey, seedRefs(rawEx, refs))
+
+		var parentID *primitive.ObjectID
+		if id, ok := ids[parentKey]; ok && parentKey != "" {
+			parentID = &id
+		}
+
+		i := slices.IndexFunc(existing, func(ex models.Exercise) bool {
+			return ex.Seed != nil && ex.Seed.Key == key || ex.Seed == nil && naturalKey(ex.Name) == key && !claimed[ex.ID]
+		})
+		if i < 0 {
+			changes.Added = append(changes.Added, rawEx.Name)
+			if opts.DryRun {
+				continue
+			}
+			want.VariationOf = parentID
+			want.Seed = &models.SeedRef{Key: key, Hash: wantHash}
+			if err := s.CreateExercise(ctx, &want); err != nil {
+				return changes, fmt.Errorf("error adding exercise %q: %w", rawEx.Name, err)
+			}
+			ids[key] = want.ID
+			continue
+		}
+
+		current := existing[i]
+		claimed[current.ID] = true
+		ids[key] = current.ID
+		currentHash := exerciseHash(current, exerciseParentKey(existing, current), storedRefs(current))
+		switch {
+		case currentHash == wantHash:
+			changes.Unchanged++
+			if current.Seed != nil && current.Seed.Hash == wantHash || opts.DryRun {
+				continue
+			}
+		case current.Seed == nil || currentHash != current.Seed.Hash:
+			changes.Kept = append(changes.Kept, rawEx.Name)
+			continue
+		default:
+			changes.Changed = append(changes.Changed, rawEx.Name)
+			if opts.DryRun {
+				continue
+			}
+			current.Name = want.Name
+			current.Aliases = want.Aliases
+			current.VariationOf = parentID
+			current.Equipment = want.Equipment
+			current.Muscles = want.Muscles
+			current.MuscleTargets = want.MuscleTargets
+			current.Mechanics = want.Mechanics
+			current.Force = want.Force
+			current.MovementPattern = want.MovementPattern
+			current.Laterality = want.Laterality
+			current.Difficulty = want.Difficulty
+			current.BodyweightLoadable = want.BodyweightLoadable
+			current.Description = want.Description
+			current.Instructions = want.Instructions
+			current.Cues = want.Cues
+			current.CommonMistakes = want.CommonMistakes
+			current.SafetyNotes = want.SafetyNotes
+		}
+
+		current.Seed = &models.SeedRef{Key: key, Hash: wantHash}
+		if err := s.UpdateExercise(ctx, current); err != nil {
+			return changes, fmt.Errorf("error updating exercise %q: %w", rawEx.Name, err)
+		}
+	}
+
+	for _, ex := range existing {
+		if ex.Seed == nil || claimed[ex.ID] {
+			continue
+		}
+		// Media are only added through the API, so such exercises are not
+		// the seed's alone anymore.
+		if len(ex.Media) > 0 || exerciseHash(ex, exerciseParentKey(existing, ex), storedRefs(ex)) != ex.Seed.Hash {
+			changes.Kept = append(changes.Kept, ex.Name)
+			continue
+		}
+
+		changes.Removed = append(changes.Removed, ex.Name)
+		if opts.DryRun || !opts.Prune {
+			continue
+		}
+		if err := pruneExercise(ctx, s, ex); err != nil {
+			return changes, err
+		}
+	}
+
+	return changes, nil
+}
+
+// pruneExercise deletes ex and moves its variations up to its own parent.
+func pruneExercise(ctx context.Context, s store.ExerciseStore, ex models.Exercise) error {
+	all, err := s.GetExercises(ctx, store.ExerciseFilter{AllOwners: true})
+	if err != nil {
+		return fmt.Errorf("error loading exercises: %w", err)
+	}
+	for _, variation := range all {
+		if variation.VariationOf == nil || *variation.VariationOf != ex.ID {
+			continue
+		}
+		variation.VariationOf = ex.VariationOf
+		if err := s.UpdateExercise(ctx, variation); err != nil {
+			return fmt.Errorf("error moving variation %q of exercise %q: %w", variation.Name, ex.Name, err)
+		}
+	}
+	if err := s.DeleteExercise(ctx, ex.ID); err != nil {
+		return fmt.Errorf("error removing exercise %q: %w", ex.Name, err)
+	}
+	return nil
+}
+
+// parentsFirst orders exercises so that each comes after the exercise it is
+// a variation of. Seed validation rules out cycles.
+func parentsFirst(exercises []models.RawExercise) []models.RawExercise {
+	byKey := make(map[string]models.RawExercise, len(exercises))
+	for _, ex := range exercises {
+		byKey[naturalKey(ex.Name)] = ex
+	}
+
+	ordered := make([]models.RawExercise, 0, len(exercises))
+	done := make(map[string]bool, len(exercises))
+	var visit func(ex models.RawExercise)
+	visit = func(ex models.RawExercise) {
+		key := naturalKey(ex.Name)
+		if done[key] {
+			return
+		}
+		done[key] = true
+		if parent, ok := byKey[naturalKey(ex.VariationOf)]; ok {
+			visit(parent)
+		}
+		ordered = append(ordered, ex)
+	}
+	for _, ex := range exercises {
+		visit(ex)
+	}
+	return ordered
+}
+
+// seedExercise converts a seed entry and refers to its options by their
+// current names, which differ from the seed if an option was renamed.
+func seedExercise(raw models.RawExercise, refs map[store.OptionKind]map[string]models.Option) models.Exercise {
+	ex := ToExercise(raw)
+	ex.Equipment = slices.Clone(ex.Equipment)
+	rename := func(kind store.OptionKind, name string) string {
+		if option, ok := refs[kind][name]; ok {
+			return option.Name
+		}
+		return name
+	}
+	for i, name := range ex.Equipment {
+		ex.Equipment[i] = rename(store.OptionEquipment, name)
+	}
+	for i, name := range ex.Muscles {
+		ex.Muscles[i] = rename(store.OptionMuscle, name)
+		ex.MuscleTargets[i].Muscle = ex.Muscles[i]
+	}
+	return ex
+}
+
+// optionRefs identify the options of an exercise by ID, so that renaming
+// an option does not count as a change of the exercise. Muscles carry
+// their role and weight.
+type optionRefs struct {
+	Equipment []string
+	Muscles   []string
+}
+
+func seedRefs(raw models.RawExercise, refs map[store.OptionKind]map[string]models.Option) optionRefs {
+	ref := func(kind store.OptionKind, name string) string {
+		if option := refs[kind][name]; !option.ID.IsZero() {
+			return option.ID.Hex()
+		}
+		return "new:" + name
+	}
+	var out optionRefs
+	for _, name := range raw.Equipment {
+		out.Equipment = append(out.Equipment, ref(store.OptionEquipment, name))
+	}
+	for _, target := range ToExercise(raw).MuscleTargets {
+		out.Muscles = append(out.Muscles, muscleRef(ref(store.OptionMuscle, target.Muscle), target.Role, constants.MuscleRoleWeights[target.Role]))
+	}
+	return out
+}
+
+func storedRefs(ex models.Exercise) optionRefs {
+	var out optionRefs
+	for _, id := range ex.EquipmentIDs {
+		out.Equipment = append(out.Equipment, id.Hex())
+	}
+	for _, target := range ex.MuscleTargets {
+		out.Muscles = append(out.Muscles, muscleRef(target.MuscleID.Hex(), target.Role, target.Weight))
+	}
+	return out
+}
+
+func muscleRef(id, role string, weight float64) string {
+	return id + " " + role + " " + strconv.FormatFloat(weight, 'g', -1, 64)
+}
+
+// exerciseHash hashes the values of ex that the seed sets. parent is the
+// seed key of the exercise it is a variation of.
+func exerciseHash(ex models.Exercise, parent string, refs optionRefs) string {
+	return hashOf(struct {
+		Name               string
+		Aliases            []string
+		Parent             string
+		Equipment          []string
+		Muscles            []string
+		Mechanics          string
+		Force              string
+		MovementPattern    string
+		Laterality         string
+		Difficulty         string
+		BodyweightLoadable *bool
+		Description        string
+		Instructions       []string
+		Cues               []string
+		CommonMistakes     []string
+		SafetyNotes        []string
+	}{
+		Name:               ex.Name,
+		Aliases:            nonEmpty(ex.Aliases),
+		Parent:             parent,
+		Equipment:          nonEmpty(slices.Sorted(slices.Values(refs.Equipment))),
+		Muscles:            nonEmpty(slices.Sorted(slices.Values(refs.Muscles))),
+		Mechanics:          ex.Mechanics,
+		Force:              ex.Force,
+		MovementPattern:    ex.MovementPattern,
+		Laterality:         ex.Laterality,
+		Difficulty:         ex.Difficulty,
+		BodyweightLoadable: ex.BodyweightLoadable,
+		Description:        ex.Description,
+		Instructions:       nonEmpty(ex.Instructions),
+		Cues:               nonEmpty(ex.Cues),
+		CommonMistakes:     nonEmpty(ex.CommonMistakes),
+		SafetyNotes:        nonEmpty(ex.SafetyNotes),
+	})
+}
+
+func optionHash(option models.Option, parent string) string {
+	return hashOf([]string{option.Name, option.Category, option.Description, parent})
+}
+
+func hashOf(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func nonEmpty(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
+// exerciseParentKey returns the seed key of the exercise ex is a variation
+// of, or its ID if it is not a catalog exercise.
+func exerciseParentKey(catalog []models.Exercise, ex models.Exercise) string {
+	if ex.VariationOf == nil {
+		return ""
+	}
+	i := slices.IndexFunc(catalog, func(c models.Exercise) bool { return c.ID == *ex.VariationOf })
+	if i < 0 {
+		return "#" + ex.VariationOf.Hex()
+	}
+	if catalog[i].Seed != nil {
+		return catalog[i].Seed.Key
+	}
+	return naturalKey(catalog[i].Name)
+}
+
+func optionParentKey(opts []models.Option, option models.Option) string {
+	if option.ParentID == nil {
+		return ""
+	}
+	i := slices.IndexFunc(opts, func(o models.Option) bool { return o.ID == *option.ParentID })
+	if i < 0 {
+		return "#" + option.ParentID.Hex()
+	}
+	if opts[i].Seed != nil {
+		return opts[i].Seed.Key
+	}
+	return opts[i].Name
+}
+
+func naturalKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
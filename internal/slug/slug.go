@@ -0,0 +1,26 @@
+package slug
+
+import (
+	"strings"
+	"unicode"
+)
+
+func Make(name string) string {
+	var b strings.Builder
+	pendingDash := false
+	for _, r := range strings.ToLower(name) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			if pendingDash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			pendingDash = false
+			continue
+		}
+		if r == '\'' {
+			continue
+		}
+		pendingDash = true
+	}
+	return b.String()
+}
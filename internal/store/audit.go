@@ -0,0 +1,25 @@
+package store
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fitness-framework-api/internal/models"
+)
+
+type AuditFilter struct {
+	UserID *primitive.ObjectID
+	Target string
+	Limit  int
+}
+
+func (f AuditFilter) Matches(event models.AuditEvent) bool {
+	return (f.UserID == nil || event.UserID == *f.UserID) && (f.Target == "" || event.Target == f.Target)
+}
+
+type AuditStore interface {
+	RecordAudit(ctx context.Context, event *models.AuditEvent) error
+	// ListAudit returns the matching events, newest first.
+	ListAudit(ctx context.Context, filter AuditFilter) ([]models.AuditEvent, error)
+}
@@ -0,0 +1,166 @@
+package store
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fitness-framework-api/internal/models"
+)
+
+type MatchMode string
+
+const (
+	MatchAny    MatchMode = "any"
+	MatchAll    MatchMode = "all"
+	MatchExact  MatchMode = "exact"
+	MatchSubset MatchMode = "subset"
+)
+
+func ParseMatchMode(s string) (MatchMode, error) {
+	switch mode := MatchMode(strings.ToLower(s)); mode {
+	case "":
+		return MatchAny, nil
+	case MatchAny, MatchAll, MatchExact, MatchSubset:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unsupported match mode %q", s)
+	}
+}
+
+type SetFilter struct {
+	Values  []string
+	Mode    MatchMode
+	Exclude []string
+	// Expansions lists, per lowercased value, the values it also matches,
+	// such as the muscles below a muscle group.
+	Expansions map[string][]string
+}
+
+func (f SetFilter) Expand(value string) []string {
+	return append([]string{value}, f.Expansions[strings.ToLower(value)]...)
+}
+
+func (f SetFilter) ExpandAll(values []string) []string {
+	var out []string
+	for _, v := range values {
+		out = append(out, f.Expand(v)...)
+	}
+	return out
+}
+
+func (f SetFilter) Matches(haystack []string) bool {
+	if containsAnyCaseInsensitive(haystack, f.ExpandAll(f.Exclude)) {
+		return false
+	}
+	if f.Mode == MatchSubset {
+		return containsAllCaseInsensitive(f.ExpandAll(f.Values), haystack)
+	}
+	if len(f.Values) == 0 {
+		return true
+	}
+
+	switch f.Mode {
+	case MatchAll:
+		return f.matchesEach(haystack)
+	case MatchExact:
+		return f.matchesEach(haystack) && containsAllCaseInsensitive(f.ExpandAll(f.Values), haystack)
+	default:
+		return containsAnyCaseInsensitive(haystack, f.ExpandAll(f.Values))
+	}
+}
+
+func (f SetFilter) matchesEach(haystack []string) bool {
+	for _, v := range f.Values {
+		if !containsAnyCaseInsensitive(haystack, f.Expand(v)) {
+			return false
+		}
+	}
+	return true
+}
+
+type ExerciseFilter struct {
+	// Query is a free-text search over names and aliases, see SearchScore.
+	Query string
+	// IDs keeps only the exercises with these IDs unless it is nil.
+	IDs []primitive.ObjectID
+
+	// Owner adds the custom exercises of this user to the shared catalog.
+	Owner *primitive.ObjectID
+	// AllOwners adds the custom exercises of every user.
+	AllOwners bool
+	// Custom keeps only custom exercises when true and only the shared
+	// catalog when false.
+	Custom *bool
+
+	Equipment   SetFilter
+	Muscles     SetFilter
+	MuscleRoles []string
+
+	Mechanics          []string
+	Force              []string
+	MovementPatterns   []string
+	Laterality         []string
+	Difficulty         []string
+	BodyweightLoadable *bool
+}
+
+func (f ExerciseFilter) Matches(ex models.Exercise) bool {
+	return f.matchesOwner(ex) &&
+		(f.IDs == nil || slices.Contains(f.IDs, ex.ID)) &&
+		MatchesQuery(ex, f.Query) &&
+		f.Equipment.Matches(ex.Equipment) &&
+		f.Muscles.Matches(MusclesWithRoles(ex, f.MuscleRoles)) &&
+		oneOf(f.Mechanics, ex.Mechanics) &&
+		oneOf(f.Force, ex.Force) &&
+		oneOf(f.MovementPatterns, ex.MovementPattern) &&
+		oneOf(f.Laterality, ex.Laterality) &&
+		oneOf(f.Difficulty, ex.Difficulty) &&
+		(f.BodyweightLoadable == nil || (ex.BodyweightLoadable != nil && *ex.BodyweightLoadable == *f.BodyweightLoadable))
+}
+
+func (f ExerciseFilter) matchesOwner(ex models.Exercise) bool {
+	if ex.OwnerID == nil {
+		return f.Custom == nil || !*f.Custom
+	}
+	if f.Custom != nil && !*f.Custom {
+		return false
+	}
+	return f.AllOwners || (f.Owner != nil && *ex.OwnerID == *f.Owner)
+}
+
+func oneOf(allowed []string, value string) bool {
+	return len(allowed) == 0 || containsAnyCaseInsensitive(allowed, []string{value})
+}
+
+func UniqueFold(values []string) []string {
+	var out []string
+	for _, v := range values {
+		if !containsAnyCaseInsensitive(out, []string{v}) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func containsAnyCaseInsensitive(haystack []string, needles []string) bool {
+	for _, needle := range needles {
+		for _, item := range haystack {
+			if strings.EqualFold(item, needle) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsAllCaseInsensitive(haystack []string, needles []string) bool {
+	for _, needle := range needles {
+		if !containsAnyCaseInsensitive(haystack, []string{needle}) {
+			return false
+		}
+	}
+	return true
+}
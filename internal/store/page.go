@@ -0,0 +1,132 @@
+package store
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+
+	"fitness-framework-api/internal/models"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+const DefaultSort = "name"
+
+var SortFields = map[string]func(models.Exercise) string{
+	"name": func(ex models.Exercise) string { return ex.Name },
+	"id":   func(ex models.Exercise) string { return "" },
+}
+
+type Cursor struct {
+	Value string             `json:"v,omitempty"`
+	ID    primitive.ObjectID `json:"id"`
+}
+
+func (c Cursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodeCursor(s string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	var c Cursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	return &c, nil
+}
+
+func CursorFor(ex models.Exercise, sortField string) Cursor {
+	return Cursor{Value: SortFields[sortField](ex), ID: ex.ID}
+}
+
+type PageRequest struct {
+	Sort   string
+	Desc   bool
+	Limit  int
+	Offset int
+	After  *Cursor
+	Before *Cursor
+}
+
+type ExercisePage struct {
+	Exercises []models.Exercise
+	Total     int64
+	HasNext   bool
+	HasPrev   bool
+}
+
+func NewCollator() *collate.Collator {
+	return collate.New(language.English, collate.IgnoreCase)
+}
+
+func compareKeys(collator *collate.Collator, aValue string, aID primitive.ObjectID, bValue string, bID primitive.ObjectID) int {
+	if c := collator.CompareString(aValue, bValue); c != 0 {
+		return c
+	}
+	return bytes.Compare(aID[:], bID[:])
+}
+
+func Paginate(exercises []models.Exercise, page PageRequest) *ExercisePage {
+	collator := NewCollator()
+	sortValue := SortFields[page.Sort]
+	direction := 1
+	if page.Desc {
+		direction = -1
+	}
+
+	slices.SortStableFunc(exercises, func(a, b models.Exercise) int {
+		return direction * compareKeys(collator, sortValue(a), a.ID, sortValue(b), b.ID)
+	})
+	afterCursor := func(ex models.Exercise, c *Cursor) int {
+		return direction * compareKeys(collator, sortValue(ex), ex.ID, c.Value, c.ID)
+	}
+
+	result := &ExercisePage{Total: int64(len(exercises))}
+	switch {
+	case page.After != nil:
+		i := 0
+		for i < len(exercises) && afterCursor(exercises[i], page.After) <= 0 {
+			i++
+		}
+		exercises = exercises[i:]
+		result.HasPrev = i > 0
+	case page.Before != nil:
+		j := 0
+		for j < len(exercises) && afterCursor(exercises[j], page.Before) < 0 {
+			j++
+		}
+		result.HasNext = j < len(exercises)
+		exercises = exercises[:j]
+		if page.Limit > 0 && len(exercises) > page.Limit {
+			exercises = exercises[len(exercises)-page.Limit:]
+			result.HasPrev = true
+		}
+		result.Exercises = exercises
+		return result
+	default:
+		if page.Offset >= len(exercises) {
+			exercises = nil
+		} else {
+			exercises = exercises[page.Offset:]
+		}
+		result.HasPrev = page.Offset > 0
+	}
+
+	if page.Limit > 0 && len(exercises) > page.Limit {
+		exercises = exercises[:page.Limit]
+		result.HasNext = true
+	}
+	result.Exercises = exercises
+	return result
+}
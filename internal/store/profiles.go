@@ -0,0 +1,25 @@
+package store
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fitness-framework-api/internal/models"
+)
+
+// ProfileStore keeps the equipment profiles of users. Profile slugs are
+// unique per user; creating or renaming a profile onto a taken slug
+// returns ErrDuplicate.
+type ProfileStore interface {
+	GetEquipmentProfiles(ctx context.Context, userID primitive.ObjectID) ([]models.EquipmentProfile, error)
+	CreateEquipmentProfile(ctx context.Context, profile *models.EquipmentProfile) error
+	UpdateEquipmentProfile(ctx context.Context, profile models.EquipmentProfile) error
+	DeleteEquipmentProfile(ctx context.Context, userID, id primitive.ObjectID) error
+	// MergeProfileEquipment replaces the equipment option from with into in
+	// every profile.
+	MergeProfileEquipment(ctx context.Context, from, into primitive.ObjectID) error
+	// RemoveProfileEquipment removes the equipment option from every
+	// profile.
+	RemoveProfileEquipment(ctx context.Context, id primitive.ObjectID) error
+}
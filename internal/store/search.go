@@ -0,0 +1,270 @@
+package store
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"fitness-framework-api/internal/models"
+)
+
+// SortRelevance orders search results by how well they match the query.
+// It is the default sort when a query is given and only supports offset
+// pagination, since scores depend on the query.
+const SortRelevance = "relevance"
+
+var stopWords = map[string]bool{"a": true, "an": true, "and": true, "of": true, "the": true, "with": true}
+
+// tokenSynonyms expands common gym shorthand that is not specific to one
+// exercise. Exercise-specific abbreviations such as RDL are stored as aliases.
+var tokenSynonyms = map[string]string{
+	"bb":  "barbell",
+	"db":  "dumbbell",
+	"dbs": "dumbbells",
+	"kb":  "kettlebell",
+}
+
+const (
+	nameWeight    = 1.0
+	contextWeight = 0.5
+	phraseBonus   = 1.0
+	exactBonus    = 2.0
+)
+
+type searchToken struct {
+	text   string
+	weight float64
+}
+
+// Tokenize lowercases s and splits it into runs of letters and digits, so
+// "Bent-Over Row" and "bent over row" produce the same tokens.
+func Tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// MatchesQuery reports whether every meaningful term of the query matches
+// the exercise. An empty query matches everything.
+func MatchesQuery(ex models.Exercise, query string) bool {
+	return len(queryTerms(query)) == 0 || SearchScore(ex, query) > 0
+}
+
+// SearchScore rates how well an exercise matches a free-text query. Each
+// query term is compared to the words of the name, aliases and translated
+// names, and with less weight to the equipment and muscles, allowing
+// prefixes and small typos. The score is zero unless every term matches
+// something.
+func SearchScore(ex models.Exercise, query string) float64 {
+	terms := queryTerms(query)
+	if len(terms) == 0 {
+		return 0
+	}
+
+	phrases := append([]string{ex.Name}, ex.Aliases...)
+	for _, t := range ex.Translations {
+		if t.Name != "" {
+			phrases = append(phrases, t.Name)
+		}
+	}
+	var tokens []searchToken
+	for _, phrase := range phrases {
+		tokens = appendTokens(tokens, Tokenize(phrase), nameWeight)
+	}
+	for _, ref := range slices.Concat(ex.Equipment, ex.Muscles) {
+		tokens = appendTokens(tokens, Tokenize(ref), contextWeight)
+	}
+
+	score := 0.0
+	for i := 0; i < len(terms); i++ {
+		best := bestMatch(terms[i], tokens)
+		// "pull up" should find "Pullup" and "pull-up" alike, so two terms
+		// can also match a single word together.
+		if i+1 < len(terms) {
+			separate := 0.0
+			if next := bestMatch(terms[i+1], tokens); best > 0 && next > 0 {
+				separate = best + next
+			}
+			if joined := 2 * bestMatch(terms[i]+terms[i+1], tokens); joined > 0 && joined >= separate {
+				score += joined
+				i++
+				continue
+			}
+		}
+		if best == 0 {
+			return 0
+		}
+		score += best
+	}
+
+	normalized := strings.Join(terms, " ")
+	bonus := 0.0
+	for _, phrase := range phrases {
+		words := Tokenize(phrase)
+		phrase = strings.Join(slices.DeleteFunc(words, func(w string) bool { return stopWords[w] }), " ")
+		switch {
+		case phrase == normalized:
+			bonus = max(bonus, exactBonus)
+		case strings.Contains(phrase, normalized):
+			bonus = max(bonus, phraseBonus)
+		}
+	}
+	return score + bonus
+}
+
+// Rank returns the exercises matching the query, best match first. Ties
+// go to the shorter name, then alphabetical order.
+func Rank(exercises []models.Exercise, query string) []models.Exercise {
+	type scored struct {
+		exercise models.Exercise
+		score    float64
+	}
+	var results []scored
+	for _, ex := range exercises {
+		if score := SearchScore(ex, query); score > 0 {
+			results = append(results, scored{ex, score})
+		}
+	}
+
+	collator := NewCollator()
+	slices.SortStableFunc(results, func(a, b scored) int {
+		if c := cmp.Compare(b.score, a.score); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(len(a.exercise.Name), len(b.exercise.Name)); c != 0 {
+			return c
+		}
+		return compareKeys(collator, a.exercise.Name, a.exercise.ID, b.exercise.Name, b.exercise.ID)
+	})
+
+	ranked := make([]models.Exercise, len(results))
+	for i, r := range results {
+		ranked[i] = r.exercise
+	}
+	return ranked
+}
+
+// PaginateSearch pages through exercises that already match query, in
+// order of relevance unless the request asks for another sort.
+func PaginateSearch(exercises []models.Exercise, query string, page PageRequest) *ExercisePage {
+	if page.Sort != SortRelevance {
+		return Paginate(exercises, page)
+	}
+
+	exercises = Rank(exercises, query)
+	if page.Desc {
+		slices.Reverse(exercises)
+	}
+	result := &ExercisePage{Total: int64(len(exercises)), HasPrev: page.Offset > 0}
+	exercises = exercises[min(page.Offset, len(exercises)):]
+	if page.Limit > 0 && len(exercises) > page.Limit {
+		exercises = exercises[:page.Limit]
+		result.HasNext = true
+	}
+	result.Exercises = exercises
+	return result
+}
+
+func queryTerms(query string) []string {
+	terms := Tokenize(query)
+	for i, term := range terms {
+		if synonym, ok := tokenSynonyms[term]; ok {
+			terms[i] = synonym
+		}
+	}
+	if meaningful := slices.DeleteFunc(slices.Clone(terms), func(t string) bool { return stopWords[t] }); len(meaningful) > 0 {
+		return meaningful
+	}
+	return terms
+}
+
+func appendTokens(tokens []searchToken, words []string, weight float64) []searchToken {
+	for i, word := range words {
+		tokens = append(tokens, searchToken{word, weight})
+		if i+1 < len(words) {
+			tokens = append(tokens, searchToken{word + words[i+1], weight})
+		}
+	}
+	return tokens
+}
+
+func bestMatch(term string, tokens []searchToken) float64 {
+	best := 0.0
+	for _, token := range tokens {
+		best = max(best, token.weight*termSimilarity(term, token.text))
+	}
+	return best
+}
+
+// termSimilarity scores a query term against a single word: 1 for an exact
+// match, slightly less for plurals and prefixes, and less again for words
+// within one or two edits, depending on the length of the term.
+func termSimilarity(term, word string) float64 {
+	switch {
+	case term == word:
+		return 1
+	case strings.TrimSuffix(term, "s") == strings.TrimSuffix(word, "s"):
+		return 0.95
+	case len(term) >= 3 && strings.HasPrefix(word, term):
+		return 0.8
+	}
+
+	allowed := 0
+	switch {
+	case len(term) >= 8:
+		allowed = 2
+	case len(term) >= 4:
+		allowed = 1
+	}
+	if allowed == 0 {
+		return 0
+	}
+	if d := editDistance(term, word, allowed); d <= allowed {
+		return 0.7 - 0.15*float64(d-1)
+	}
+	// A typo in a term that is still being typed, such as "deadlfi".
+	if runes := []rune(word); len(runes) > len([]rune(term)) {
+		if d := editDistance(term, string(runes[:len([]rune(term))]), allowed); d <= allowed {
+			return 0.5 - 0.15*float64(d-1)
+		}
+	}
+	return 0
+}
+
+// editDistance returns the optimal string alignment distance between a and
+// b, counting an adjacent transposition as one edit. It stops early and
+// returns limit+1 once the distance is known to exceed limit.
+func editDistance(a, b string, limit int) int {
+	ra, rb := []rune(a), []rune(b)
+	if d := len(ra) - len(rb); d > limit || -d > limit {
+		return limit + 1
+	}
+
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+			rowMin = min(rowMin, curr[j])
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(rb)]
+}
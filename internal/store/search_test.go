@@ -0,0 +1,115 @@
+package store
+
+import (
+	"encoding/json"
+	"os"
+	"slices"
+	"testing"
+
+	"fitness-framework-api/internal/models"
+)
+
+// searchCorpus returns the catalog of the seed file that the server loads
+// by default.
+func searchCorpus(t *testing.T) []models.Exercise {
+	t.Helper()
+	data, err := os.ReadFile("../../data/exercises.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var raw []models.RawExercise
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatal(err)
+	}
+
+	corpus := make([]models.Exercise, len(raw))
+	for i, r := range raw {
+		corpus[i] = models.Exercise{
+			Name:      r.Name,
+			Aliases:   r.Aliases,
+			Equipment: r.Equipment,
+			Muscles:   slices.Concat(r.Muscles, r.SecondaryMuscles, r.StabilizerMuscles),
+		}
+	}
+	return corpus
+}
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"Barbell Bent-Over Row", []string{"barbell", "bent", "over", "row"}},
+		{"bent over row", []string{"bent", "over", "row"}},
+		{"Lat Pulldown Behind-the-Head", []string{"lat", "pulldown", "behind", "the", "head"}},
+		{"Leg Curl + Isometric Hold", []string{"leg", "curl", "isometric", "hold"}},
+		{"Chin-Up", []string{"chin", "up"}},
+		{"RDL", []string{"rdl"}},
+		{"  ", nil},
+	}
+	for _, tt := range tests {
+		if got := Tokenize(tt.in); !slices.Equal(got, tt.want) {
+			t.Errorf("Tokenize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTermSimilarity(t *testing.T) {
+	tests := []struct {
+		term, word string
+		want       float64
+	}{
+		{"row", "row", 1},
+		{"shrug", "shrugs", 0.95},
+		{"dead", "deadlift", 0.8},
+		{"pullup", "pullup", 1},
+		{"dedlift", "deadlift", 0.7},
+		{"romanain", "romanian", 0.7},
+		{"romnain", "romanian", 0},
+		{"deadlfi", "deadlift", 0.5},
+		{"pu", "pullup", 0},
+		{"lat", "leg", 0},
+		{"curl", "row", 0},
+	}
+	for _, tt := range tests {
+		if got := termSimilarity(tt.term, tt.word); got != tt.want {
+			t.Errorf("termSimilarity(%q, %q) = %v, want %v", tt.term, tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestRank(t *testing.T) {
+	corpus := searchCorpus(t)
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"abbreviation", "RDL", []string{"Barbell Romanian Deadlift"}},
+		{"abbreviation ignores case", "rdl", []string{"Barbell Romanian Deadlift"}},
+		{"hyphen", "chin-up", []string{"Chin-Up"}},
+		{"space for hyphen", "chin up", []string{"Chin-Up"}},
+		{"joined words", "chinup", []string{"Chin-Up"}},
+		{"alias phrase", "bent over row", []string{"Barbell Bent-Over Row", "Dumbbell Bent-Over Row", "Smith Machine Bent-Over Row"}},
+		{"word order", "row bent over", []string{"Barbell Bent-Over Row", "Dumbbell Bent-Over Row", "Smith Machine Bent-Over Row"}},
+		{"typo", "dedlift", []string{"Barbell Deadlift", "Barbell Sumo Deadlift", "Barbell Romanian Deadlift"}},
+		{"pullup", "pullup", []string{"Pullup"}},
+		{"pull up", "pull up", []string{"Pullup"}},
+		{"synonym", "db shoulder press", []string{"Dumbbell Shoulder Press"}},
+		{"no match", "snatch", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, ex := range Rank(corpus, tt.query) {
+				got = append(got, ex.Name)
+			}
+			if tt.want != nil && len(got) > len(tt.want) {
+				got = got[:len(tt.want)]
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Rank(%q) starts with %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
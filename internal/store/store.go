@@ -0,0 +1,91 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fitness-framework-api/internal/models"
+)
+
+var (
+	ErrNotFound      = errors.New("not found")
+	ErrDuplicate     = errors.New("duplicate")
+	ErrUnknownOption = errors.New("unknown option")
+)
+
+type OptionKind string
+
+const (
+	OptionEquipment OptionKind = "equipment"
+	OptionMuscle    OptionKind = "muscle"
+)
+
+type ExerciseStore interface {
+	GetExercises(ctx context.Context, filter ExerciseFilter) ([]models.Exercise, error)
+	ListExercises(ctx context.Context, filter ExerciseFilter, page PageRequest) (*ExercisePage, error)
+	GetExercise(ctx context.Context, id primitive.ObjectID) (*models.Exercise, error)
+	GetExerciseBySlug(ctx context.Context, slug string) (*models.Exercise, error)
+	FindExerciseByName(ctx context.Context, name string) (*models.Exercise, error)
+	GetUniqueExerciseNames(ctx context.Context) ([]string, error)
+	GetUniqueEquipment(ctx context.Context) ([]string, error)
+	GetUniqueMuscles(ctx context.Context) ([]string, error)
+	CreateExercise(ctx context.Context, exercise *models.Exercise) error
+	UpdateExercise(ctx context.Context, exercise models.Exercise) error
+	DeleteExercise(ctx context.Context, id primitive.ObjectID) error
+	AddExerciseMedia(ctx context.Context, exerciseID primitive.ObjectID, media models.Media) error
+	RemoveExerciseMedia(ctx context.Context, exerciseID, mediaID primitive.ObjectID) error
+	GetOptions(ctx context.Context, kind OptionKind) ([]models.Option, error)
+	CreateOption(ctx context.Context, kind OptionKind, option *models.Option) error
+	UpdateOption(ctx context.Context, kind OptionKind, option models.Option) error
+	DeleteOption(ctx context.Context, kind OptionKind, id primitive.ObjectID) error
+	CountOptionUsage(ctx context.Context, kind OptionKind, id primitive.ObjectID) (int64, error)
+	ReplaceOptionInExercises(ctx context.Context, kind OptionKind, from models.Option, into *models.Option) (int64, error)
+	Close(ctx context.Context) error
+}
+
+func ResolveOptions(names []string, registered []models.Option) ([]string, []primitive.ObjectID, error) {
+	canonical := make([]string, 0, len(names))
+	ids := make([]primitive.ObjectID, 0, len(names))
+	for _, name := range names {
+		i := slices.IndexFunc(registered, func(o models.Option) bool { return strings.EqualFold(o.Name, name) })
+		if i < 0 {
+			return nil, nil, fmt.Errorf("%w: %q", ErrUnknownOption, name)
+		}
+		canonical = append(canonical, registered[i].Name)
+		ids = append(ids, registered[i].ID)
+	}
+	return canonical, ids, nil
+}
+
+func SortOptionRefs(exercise *models.Exercise) {
+	exercise.Equipment, exercise.EquipmentIDs = SortRefs(exercise.Equipment, exercise.EquipmentIDs)
+	exercise.Muscles, exercise.MuscleIDs = SortRefs(exercise.Muscles, exercise.MuscleIDs)
+	sortMuscleTargets(exercise.MuscleTargets)
+}
+
+// SortRefs sorts option names by name and keeps their IDs in step.
+func SortRefs(names []string, ids []primitive.ObjectID) ([]string, []primitive.ObjectID) {
+	if len(names) != len(ids) {
+		slices.Sort(names)
+		return names, ids
+	}
+
+	order := make([]int, len(names))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return strings.Compare(names[a], names[b]) })
+
+	sortedNames := make([]string, len(names))
+	sortedIDs := make([]primitive.ObjectID, len(ids))
+	for i, j := range order {
+		sortedNames[i] = names[j]
+		sortedIDs[i] = ids[j]
+	}
+	return sortedNames, sortedIDs
+}
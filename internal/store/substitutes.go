@@ -0,0 +1,169 @@
+package store
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fitness-framework-api/internal/constants"
+	"fitness-framework-api/internal/models"
+)
+
+const (
+	substituteMuscleWeight    = 0.6
+	substituteEquipmentWeight = 0.25
+	substituteVariationWeight = 0.1
+	substitutePatternWeight   = 0.05
+	// relatedMuscleCredit is the share of a muscle's work that counts when a
+	// substitute trains a muscle above or below it in the hierarchy instead.
+	relatedMuscleCredit = 0.5
+)
+
+type Substitute struct {
+	Exercise         models.Exercise `json:"exercise"`
+	Score            float64         `json:"score"`
+	MuscleOverlap    float64         `json:"muscleOverlap"`
+	SharedMuscles    []string        `json:"sharedMuscles"`
+	MissingEquipment []string        `json:"missingEquipment,omitempty"`
+	Variation        bool            `json:"variation"`
+}
+
+type SubstituteOptions struct {
+	// Available is the equipment the caller has. Nil means unknown, in
+	// which case equipment does not affect the ranking.
+	Available []string
+	// AvailableOnly drops substitutes that need equipment outside Available.
+	AvailableOnly bool
+	// ExcludeEquipment drops substitutes that use any of this equipment.
+	ExcludeEquipment []string
+	// Expansions is the muscle hierarchy, as returned by Descendants.
+	Expansions map[string][]string
+}
+
+// RankSubstitutes returns the exercises of the catalog that can replace
+// original, best first. Substitutes must share at least one muscle. They
+// rank higher the more of the original's muscle work they cover, the more
+// of their equipment is available, and when they are variations of the
+// same exercise or follow the same movement pattern.
+func RankSubstitutes(original models.Exercise, catalog []models.Exercise, opts SubstituteOptions) []Substitute {
+	roots := variationRoots(catalog)
+	exclude := SetFilter{Exclude: opts.ExcludeEquipment}
+	wanted := MuscleInvolvement(original)
+
+	var total float64
+	for _, weight := range wanted {
+		total += weight
+	}
+	if total == 0 {
+		return nil
+	}
+
+	var substitutes []Substitute
+	for _, candidate := range catalog {
+		if candidate.ID == original.ID || !exclude.Matches(candidate.Equipment) {
+			continue
+		}
+
+		var covered float64
+		var shared []string
+		offered := MuscleInvolvement(candidate)
+		for muscle, weight := range wanted {
+			credit := 0.0
+			for other, otherWeight := range offered {
+				switch {
+				case strings.EqualFold(muscle, other):
+					credit = max(credit, min(weight, otherWeight))
+				case relatedMuscles(opts.Expansions, muscle, other):
+					credit = max(credit, relatedMuscleCredit*min(weight, otherWeight))
+				}
+			}
+			if credit > 0 {
+				covered += credit
+				shared = append(shared, muscle)
+			}
+		}
+		if len(shared) == 0 {
+			continue
+		}
+
+		sub := Substitute{
+			Exercise:      candidate,
+			MuscleOverlap: round(covered / total),
+			SharedMuscles: slices.Sorted(slices.Values(shared)),
+			Variation:     roots[candidate.ID] == roots[original.ID] && roots[original.ID] != primitive.NilObjectID,
+		}
+		score := substituteMuscleWeight * covered / total
+
+		if opts.Available != nil {
+			for _, equipment := range candidate.Equipment {
+				if !strings.EqualFold(equipment, constants.EquipmentNone) && !containsAnyCaseInsensitive(opts.Available, []string{equipment}) {
+					sub.MissingEquipment = append(sub.MissingEquipment, equipment)
+				}
+			}
+			if opts.AvailableOnly && len(sub.MissingEquipment) > 0 {
+				continue
+			}
+			available := 1.0
+			if len(candidate.Equipment) > 0 {
+				available -= float64(len(sub.MissingEquipment)) / float64(len(candidate.Equipment))
+			}
+			score += substituteEquipmentWeight * available
+		}
+		if sub.Variation {
+			score += substituteVariationWeight
+		}
+		if original.MovementPattern != "" && original.MovementPattern == candidate.MovementPattern {
+			score += substitutePatternWeight
+		}
+		sub.Score = round(score)
+		substitutes = append(substitutes, sub)
+	}
+
+	collator := NewCollator()
+	slices.SortStableFunc(substitutes, func(a, b Substitute) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return compareKeys(collator, a.Exercise.Name, a.Exercise.ID, b.Exercise.Name, b.Exercise.ID)
+	})
+	return substitutes
+}
+
+// variationRoots maps each exercise to the exercise at the top of its
+// variation chain. Exercises that are not a variation are their own root.
+func variationRoots(catalog []models.Exercise) map[primitive.ObjectID]primitive.ObjectID {
+	parents := make(map[primitive.ObjectID]primitive.ObjectID, len(catalog))
+	for _, ex := range catalog {
+		if ex.VariationOf != nil {
+			parents[ex.ID] = *ex.VariationOf
+		}
+	}
+
+	roots := make(map[primitive.ObjectID]primitive.ObjectID, len(catalog))
+	for _, ex := range catalog {
+		root := ex.ID
+		visited := map[primitive.ObjectID]bool{root: true}
+		for {
+			parent, ok := parents[root]
+			if !ok || visited[parent] {
+				break
+			}
+			visited[parent] = true
+			root = parent
+		}
+		roots[ex.ID] = root
+	}
+	return roots
+}
+
+func relatedMuscles(expansions map[string][]string, a, b string) bool {
+	return containsAnyCaseInsensitive(expansions[strings.ToLower(a)], []string{b}) ||
+		containsAnyCaseInsensitive(expansions[strings.ToLower(b)], []string{a})
+}
+
+func round(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
@@ -0,0 +1,79 @@
+package store
+
+import (
+	"slices"
+	"strings"
+
+	"fitness-framework-api/internal/constants"
+	"fitness-framework-api/internal/models"
+)
+
+func AlignMuscleTargets(exercise *models.Exercise) {
+	targets := make([]models.MuscleTarget, 0, len(exercise.Muscles))
+	for i, name := range exercise.Muscles {
+		target := models.MuscleTarget{Muscle: name, Role: constants.MuscleRolePrimary}
+		if i < len(exercise.MuscleIDs) {
+			target.MuscleID = exercise.MuscleIDs[i]
+		}
+		if j := slices.IndexFunc(exercise.MuscleTargets, func(t models.MuscleTarget) bool {
+			return strings.EqualFold(t.Muscle, name) || (!t.MuscleID.IsZero() && t.MuscleID == target.MuscleID)
+		}); j >= 0 && exercise.MuscleTargets[j].Role != "" {
+			target.Role = strings.ToLower(exercise.MuscleTargets[j].Role)
+			target.Weight = exercise.MuscleTargets[j].Weight
+		}
+		if target.Weight == 0 {
+			target.Weight = constants.MuscleRoleWeights[target.Role]
+		}
+		targets = append(targets, target)
+	}
+	exercise.MuscleTargets = targets
+}
+
+func MusclesWithRoles(exercise models.Exercise, roles []string) []string {
+	if len(roles) == 0 {
+		return exercise.Muscles
+	}
+	var muscles []string
+	for _, target := range exercise.MuscleTargets {
+		if containsAnyCaseInsensitive(roles, []string{target.Role}) {
+			muscles = append(muscles, target.Muscle)
+		}
+	}
+	return muscles
+}
+
+func MuscleInvolvement(exercise models.Exercise) map[string]float64 {
+	involvement := make(map[string]float64, len(exercise.MuscleTargets))
+	for _, target := range exercise.MuscleTargets {
+		involvement[target.Muscle] = max(involvement[target.Muscle], target.Weight)
+	}
+	return involvement
+}
+
+func sortMuscleTargets(targets []models.MuscleTarget) {
+	slices.SortStableFunc(targets, func(a, b models.MuscleTarget) int {
+		if ra, rb := slices.Index(constants.AllMuscleRoles, a.Role), slices.Index(constants.AllMuscleRoles, b.Role); ra != rb {
+			return ra - rb
+		}
+		return strings.Compare(a.Muscle, b.Muscle)
+	})
+}
+
+func ReplaceMuscleTarget(targets []models.MuscleTarget, from models.Option, into *models.Option) []models.MuscleTarget {
+	out := make([]models.MuscleTarget, 0, len(targets))
+	for _, target := range targets {
+		if target.MuscleID != from.ID {
+			out = append(out, target)
+			continue
+		}
+		if into == nil {
+			continue
+		}
+		if into.ID != from.ID && slices.ContainsFunc(targets, func(t models.MuscleTarget) bool { return t.MuscleID == into.ID }) {
+			continue
+		}
+		target.Muscle, target.MuscleID = into.Name, into.ID
+		out = append(out, target)
+	}
+	return out
+}
@@ -0,0 +1,52 @@
+package store
+
+import (
+	"context"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fitness-framework-api/internal/models"
+)
+
+// DeleteOption deletes an option that no exercise uses any more. Deleted
+// equipment is first removed from the equipment profiles, so that no
+// profile keeps pointing at it.
+func DeleteOption(ctx context.Context, exercises ExerciseStore, profiles ProfileStore, kind OptionKind, id primitive.ObjectID) error {
+	if kind == OptionEquipment {
+		if err := profiles.RemoveProfileEquipment(ctx, id); err != nil {
+			return err
+		}
+	}
+	return exercises.DeleteOption(ctx, kind, id)
+}
+
+func Descendants(opts []models.Option) map[string][]string {
+	children := make(map[string][]models.Option)
+	for _, option := range opts {
+		if option.ParentID != nil {
+			children[option.ParentID.Hex()] = append(children[option.ParentID.Hex()], option)
+		}
+	}
+
+	descendants := make(map[string][]string)
+	for _, option := range opts {
+		var names []string
+		visited := map[string]bool{option.ID.Hex(): true}
+		queue := children[option.ID.Hex()]
+		for len(queue) > 0 {
+			child := queue[0]
+			queue = queue[1:]
+			if visited[child.ID.Hex()] {
+				continue
+			}
+			visited[child.ID.Hex()] = true
+			names = append(names, child.Name)
+			queue = append(queue, children[child.ID.Hex()]...)
+		}
+		if len(names) > 0 {
+			descendants[strings.ToLower(option.Name)] = names
+		}
+	}
+	return descendants
+}
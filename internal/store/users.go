@@ -0,0 +1,38 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fitness-framework-api/internal/models"
+)
+
+type UserStore interface {
+	// CreateUser returns ErrDuplicate if the email is already registered.
+	CreateUser(ctx context.Context, user *models.User) error
+	GetUser(ctx context.Context, id primitive.ObjectID) (*models.User, error)
+	FindUserByEmail(ctx context.Context, email string) (*models.User, error)
+	ListUsers(ctx context.Context) ([]models.User, error)
+	SetUserRole(ctx context.Context, id primitive.ObjectID, role models.Role) error
+	CreateSession(ctx context.Context, session *models.Session) error
+	FindSession(ctx context.Context, tokenHash string) (*models.Session, error)
+	DeleteSession(ctx context.Context, id primitive.ObjectID) error
+	DeleteUserSessions(ctx context.Context, userID primitive.ObjectID) error
+	CreateAPIKey(ctx context.Context, key *models.APIKey) error
+	ListAPIKeys(ctx context.Context, userID primitive.ObjectID) ([]models.APIKey, error)
+	FindAPIKey(ctx context.Context, keyHash string) (*models.APIKey, error)
+	// RevokeAPIKey returns ErrNotFound unless the key belongs to userID and
+	// is not revoked yet.
+	RevokeAPIKey(ctx context.Context, userID, id primitive.ObjectID, at time.Time) error
+}
+
+// Store is everything the API persists.
+type Store interface {
+	ExerciseStore
+	UserStore
+	AuditStore
+	ProfileStore
+	WorkoutStore
+}
@@ -0,0 +1,47 @@
+package store
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"fitness-framework-api/internal/models"
+)
+
+// WorkoutFilter selects workouts of a user. From and To bound the start
+// time; From is inclusive and To exclusive.
+type WorkoutFilter struct {
+	UserID     primitive.ObjectID
+	From       *time.Time
+	To         *time.Time
+	ExerciseID *primitive.ObjectID
+	Limit      int
+}
+
+func (f WorkoutFilter) Matches(workout models.Workout) bool {
+	if workout.UserID != f.UserID {
+		return false
+	}
+	if f.From != nil && workout.StartedAt.Before(*f.From) {
+		return false
+	}
+	if f.To != nil && !workout.StartedAt.Before(*f.To) {
+		return false
+	}
+	return f.ExerciseID == nil || slices.ContainsFunc(workout.Exercises, func(e models.WorkoutExercise) bool {
+		return e.ExerciseID == *f.ExerciseID
+	})
+}
+
+// WorkoutStore keeps the workout log of users. Workouts are only found
+// together with the ID of the user they belong to.
+type WorkoutStore interface {
+	// ListWorkouts returns the matching workouts, latest start first.
+	ListWorkouts(ctx context.Context, filter WorkoutFilter) ([]models.Workout, error)
+	GetWorkout(ctx context.Context, userID, id primitive.ObjectID) (*models.Workout, error)
+	CreateWorkout(ctx context.Context, workout *models.Workout) error
+	UpdateWorkout(ctx context.Context, workout models.Workout) error
+	DeleteWorkout(ctx context.Context, userID, id primitive.ObjectID) error
+}
@@ -1,50 +1,297 @@
-package main
-
-import (
-	"context"
-	"log/slog"
-	"net/http"
-
-	"fitness-framework-api/internal/handlers"
-	"fitness-framework-api/internal/mongodb"
-	"fitness-framework-api/internal/version"
-)
-
-const (
-	PORT = ":9001"
-)
-
-func main() {
-	db, err := mongodb.InitDB("workout_app")
-	if err != nil {
-		slog.Error("Failed to initialize database", "error", err)
-	}
-	defer func() {
-		if db != nil {
-			err := db.Client().Disconnect(context.Background())
-			if err != nil {
-				slog.Error("Error disconnecting from MongoDB", "error", err)
-			} else {
-				slog.Info("MongoDB client disconnected.")
-			}
-		}
-	}()
-
-	apiInfo, err := version.LoadVersionInfo()
-	if err != nil {
-		slog.Error("Failed to load version information", "error", err)
-	}
-
-	apiHandlers := handlers.NewAPI(db, apiInfo)
-
-	http.HandleFunc("/api/version", apiHandlers.GetVersionHandler)
-	http.HandleFunc("/api/exercises", apiHandlers.GetExercisesHandler)
-	http.HandleFunc("/api/equipment-options", apiHandlers.GetEquipmentOptionsHandler)
-	http.HandleFunc("/api/muscles-options", apiHandlers.GetMusclesOptionsHandler)
-
-	slog.Info("Server starting on", "port", PORT)
-	err = http.ListenAndServe(PORT, nil)
-	if err != nil {
-		slog.Error("Server failed to start", "error", err)
-	}
-}
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"fitness-framework-api/internal/auth"
+	"fitness-framework-api/internal/handlers"
+	"fitness-framework-api/internal/i18n"
+	"fitness-framework-api/internal/media"
+	"fitness-framework-api/internal/memory"
+	"fitness-framework-api/internal/migrations"
+	"fitness-framework-api/internal/models"
+	"fitness-framework-api/internal/mongodb"
+	"fitness-framework-api/internal/seed"
+	"fitness-framework-api/internal/store"
+	"fitness-framework-api/internal/version"
+)
+
+const (
+	PORT         = ":9001"
+	DatabaseName = "workout_app"
+)
+
+func main() {
+	storeType := flag.String("store", "mongo", "storage backend to use: mongo or memory")
+	seedPath := flag.String("seed", seed.DefaultPath, "path to the JSON or YAML exercise seed file")
+	syncDryRun := flag.Bool("sync-dry-run", false, "report catalog changes against the seed file and exit without applying them")
+	syncPrune := flag.Bool("sync-prune", false, "remove catalog entries that are no longer in the seed file")
+	optionsFormat := flag.String("options-format", handlers.OptionsFormatNames, "default response format of the options endpoints: objects, names or tree")
+	autoMigrate := flag.Bool("migrate", true, "apply pending schema migrations on startup (mongo store only)")
+	mediaDir := flag.String("media-dir", "./data/media", "directory where uploaded exercise media is stored")
+	translationsDir := flag.String("translations", "./data/translations", "directory of translation files imported on startup, one per locale")
+	mediaURL := flag.String("media-url", handlers.DefaultMediaBaseURL, "base URL under which media files are served, e.g. a CDN in front of -media-dir")
+	authSecret := flag.String("auth-secret", os.Getenv("AUTH_SECRET"), "hex-encoded key that signs access tokens (default $AUTH_SECRET, or a random key per run)")
+	accessTTL := flag.Duration("access-token-ttl", auth.DefaultAccessTokenTTL, "lifetime of access tokens")
+	refreshTTL := flag.Duration("refresh-token-ttl", auth.DefaultRefreshTokenTTL, "lifetime of refresh tokens")
+	adminEmail := flag.String("admin-email", "", "email of a registered user who is given the admin role on startup")
+	flag.Parse()
+
+	s, err := openStore(*storeType)
+	if err != nil {
+		slog.Error("Failed to initialize store", "store", *storeType, "error", err)
+		os.Exit(1)
+	}
+	defer func() {
+		err := s.Close(context.Background())
+		if err != nil {
+			slog.Error("Error closing store", "error", err)
+		} else {
+			slog.Info("Store closed.")
+		}
+	}()
+
+	var migrator *migrations.Migrator
+	if mongoStore, ok := s.(*mongodb.Store); ok {
+		migrator = migrations.NewMigrator(mongoStore.DB)
+	}
+
+	if args := flag.Args(); len(args) > 0 {
+		if err := runCommand(context.Background(), migrator, args); err != nil {
+			slog.Error("Command failed", "command", args[0], "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if migrator != nil && *autoMigrate {
+		applied, err := migrator.Up(context.Background(), 0)
+		if err != nil {
+			slog.Error("Failed to apply migrations", "error", err)
+			os.Exit(1)
+		}
+		slog.Info("Schema migrations up to date", "applied", len(applied))
+	}
+
+	seedExercises, err := seed.Load(*seedPath)
+	if err != nil {
+		slog.Error("Failed to load seed data", "error", err)
+		os.Exit(1)
+	}
+
+	report, err := seed.Sync(context.Background(), s, seedExercises, seed.SyncOptions{DryRun: *syncDryRun, Prune: *syncPrune})
+	if err != nil {
+		slog.Error("Failed to sync catalog", "error", err)
+		os.Exit(1)
+	}
+	report.Log()
+
+	if *syncDryRun {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(report)
+		return
+	}
+
+	if err := importTranslations(context.Background(), s, *translationsDir); err != nil {
+		slog.Error("Failed to import translations", "error", err)
+		os.Exit(1)
+	}
+
+	apiInfo, err := version.LoadVersionInfo()
+	if err != nil {
+		slog.Error("Failed to load version information", "error", err)
+	}
+
+	issuer, err := newIssuer(*authSecret, *accessTTL, *refreshTTL)
+	if err != nil {
+		slog.Error("Invalid auth configuration", "error", err)
+		os.Exit(1)
+	}
+
+	if err := promoteAdmin(context.Background(), s, *adminEmail); err != nil {
+		slog.Error("Failed to promote admin", "error", err)
+		os.Exit(1)
+	}
+
+	apiHandlers := handlers.NewAPI(s, apiInfo)
+	apiHandlers.Users = s
+	apiHandlers.Audit = s
+	apiHandlers.Profiles = s
+	apiHandlers.Workouts = s
+	apiHandlers.Auth = issuer
+	apiHandlers.Migrator = migrator
+	apiHandlers.OptionsFormat = *optionsFormat
+	apiHandlers.MediaBaseURL = *mediaURL
+	apiHandlers.Blobs, err = media.NewLocalStore(*mediaDir)
+	if err != nil {
+		slog.Error("Failed to initialize media storage", "error", err)
+		os.Exit(1)
+	}
+
+	http.HandleFunc("/api/version", apiHandlers.GetVersionHandler)
+	http.HandleFunc("/api/exercises", apiHandlers.RequireRoleToWrite(models.RoleAdmin, apiHandlers.ExercisesHandler))
+	http.HandleFunc("/api/exercises/available", apiHandlers.OptionalAuth(apiHandlers.GetAvailableExercisesHandler))
+	http.HandleFunc("/api/exercises/{id}", apiHandlers.RequireRoleToWrite(models.RoleUser, apiHandlers.ExerciseHandler))
+	http.HandleFunc("/api/exercises/{id}/promote", apiHandlers.RequireRole(models.RoleAdmin, apiHandlers.PromoteExerciseHandler))
+	http.HandleFunc("/api/exercises/{id}/substitutes", apiHandlers.OptionalAuth(apiHandlers.ExerciseSubstitutesHandler))
+	http.HandleFunc("/api/exercises/{id}/media", apiHandlers.RequireRoleToWrite(models.RoleUser, apiHandlers.ExerciseMediaHandler))
+	http.HandleFunc("/api/exercises/{id}/media/{mediaId}", apiHandlers.RequireRoleToWrite(models.RoleUser, apiHandlers.ExerciseMediaItemHandler))
+	http.HandleFunc("/api/media/{key...}", apiHandlers.MediaFileHandler)
+	http.HandleFunc("/api/translations", apiHandlers.TranslationsHandler)
+	http.HandleFunc("/api/translations/{locale}", apiHandlers.RequireRoleToWrite(models.RoleAdmin, apiHandlers.TranslationHandler))
+	http.HandleFunc("/api/auth/register", apiHandlers.RegisterHandler)
+	http.HandleFunc("/api/auth/login", apiHandlers.LoginHandler)
+	http.HandleFunc("/api/auth/refresh", apiHandlers.RefreshHandler)
+	http.HandleFunc("/api/auth/logout", apiHandlers.RequireAuth(apiHandlers.LogoutHandler))
+	http.HandleFunc("/api/users", apiHandlers.RequireRole(models.RoleAdmin, apiHandlers.UsersHandler))
+	http.HandleFunc("/api/users/{id}/role", apiHandlers.RequireRole(models.RoleAdmin, apiHandlers.UserRoleHandler))
+	http.HandleFunc("/api/users/me", apiHandlers.RequireAuth(apiHandlers.CurrentUserHandler))
+	http.HandleFunc("/api/users/me/exercises", apiHandlers.RequireAuth(apiHandlers.CustomExercisesHandler))
+	http.HandleFunc("/api/users/me/gyms", apiHandlers.RequireAuth(apiHandlers.GymsHandler))
+	http.HandleFunc("/api/users/me/gyms/{gym}", apiHandlers.RequireAuth(apiHandlers.GymHandler))
+	http.HandleFunc("/api/users/me/api-keys", apiHandlers.RequireAuth(apiHandlers.APIKeysHandler))
+	http.HandleFunc("/api/users/me/api-keys/{keyId}", apiHandlers.RequireAuth(apiHandlers.APIKeyHandler))
+	http.HandleFunc("/api/workouts", apiHandlers.RequireAuth(apiHandlers.WorkoutsHandler))
+	http.HandleFunc("/api/workouts/{workoutId}", apiHandlers.RequireAuth(apiHandlers.WorkoutHandler))
+	http.HandleFunc("/api/audit", apiHandlers.RequireRole(models.RoleAdmin, apiHandlers.AuditHandler))
+	http.HandleFunc("/api/equipment-options", apiHandlers.RequireRoleToWrite(models.RoleAdmin, apiHandlers.EquipmentOptionsHandler))
+	http.HandleFunc("/api/equipment-options/{option}", apiHandlers.RequireRoleToWrite(models.RoleAdmin, apiHandlers.EquipmentOptionHandler))
+	http.HandleFunc("/api/equipment-options/{option}/merge", apiHandlers.RequireRole(models.RoleAdmin, apiHandlers.MergeEquipmentOptionHandler))
+	http.HandleFunc("/api/muscles-options", apiHandlers.RequireRoleToWrite(models.RoleAdmin, apiHandlers.MusclesOptionsHandler))
+	http.HandleFunc("/api/muscles-options/{option}", apiHandlers.RequireRoleToWrite(models.RoleAdmin, apiHandlers.MusclesOptionHandler))
+	http.HandleFunc("/api/muscles-options/{option}/merge", apiHandlers.RequireRole(models.RoleAdmin, apiHandlers.MergeMusclesOptionHandler))
+
+	slog.Info("Server starting on", "port", PORT)
+	err = http.ListenAndServe(PORT, nil)
+	if err != nil {
+		slog.Error("Server failed to start", "error", err)
+	}
+}
+
+func openStore(storeType string) (store.Store, error) {
+	switch storeType {
+	case "memory":
+		slog.Info("Using in-memory store.")
+		return memory.NewStore(), nil
+	case "mongo":
+		return mongodb.InitDB(DatabaseName)
+	default:
+		return nil, fmt.Errorf("unknown store type %q", storeType)
+	}
+}
+
+func newIssuer(secret string, accessTTL, refreshTTL time.Duration) (*auth.Issuer, error) {
+	if accessTTL <= 0 || refreshTTL <= 0 {
+		return nil, fmt.Errorf("token lifetimes must be positive")
+	}
+
+	var key []byte
+	if secret == "" {
+		slog.Warn("No auth secret configured; tokens will not survive a restart")
+		key = auth.NewSecret()
+	} else {
+		var err error
+		if key, err = hex.DecodeString(secret); err != nil || len(key) < 32 {
+			return nil, fmt.Errorf("auth secret must be at least 32 hex-encoded bytes")
+		}
+	}
+
+	issuer := auth.NewIssuer(key)
+	issuer.AccessTTL = accessTTL
+	issuer.RefreshTTL = refreshTTL
+	return issuer, nil
+}
+
+// promoteAdmin gives the admin role to the user with the given email, if
+// they are registered. This is how the first admin is made; registering
+// never grants a role above user.
+func promoteAdmin(ctx context.Context, s store.UserStore, email string) error {
+	if email == "" {
+		return nil
+	}
+	user, err := s.FindUserByEmail(ctx, strings.ToLower(strings.TrimSpace(email)))
+	if errors.Is(err, store.ErrNotFound) {
+		slog.Warn("Admin email is not registered; restart after they register to make them admin", "email", email)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if user.Role == models.RoleAdmin {
+		return nil
+	}
+	slog.Info("Giving admin role to user", "email", user.Email)
+	return s.SetUserRole(ctx, user.ID, models.RoleAdmin)
+}
+
+func importTranslations(ctx context.Context, s store.ExerciseStore, dir string) error {
+	files, err := i18n.LoadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		result, err := i18n.Import(ctx, s, f)
+		if err != nil {
+			return fmt.Errorf("locale %q: %w", f.Locale, err)
+		}
+		slog.Info("Imported translations", "locale", result.Locale, "exercises", result.Exercises, "equipment", result.Equipment, "muscles", result.Muscles, "updated", result.Updated)
+	}
+	return nil
+}
+
+func runCommand(ctx context.Context, migrator *migrations.Migrator, args []string) error {
+	if args[0] != "migrate" {
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+	if migrator == nil {
+		return fmt.Errorf("migrations are only supported with the mongo store")
+	}
+	if len(args) < 2 {
+		return fmt.Errorf("usage: migrate up [version] | down [steps] | status")
+	}
+
+	var n int
+	if len(args) > 2 {
+		var err error
+		n, err = strconv.Atoi(args[2])
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid number %q", args[2])
+		}
+	}
+
+	switch args[1] {
+	case "up":
+		applied, err := migrator.Up(ctx, n)
+		slog.Info("Applied migrations", "versions", applied)
+		return err
+	case "down":
+		if n == 0 {
+			n = 1
+		}
+		reverted, err := migrator.Down(ctx, n)
+		slog.Info("Reverted migrations", "versions", reverted)
+		return err
+	case "status":
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(status)
+	default:
+		return fmt.Errorf("unknown migrate subcommand %q", args[1])
+	}
+}